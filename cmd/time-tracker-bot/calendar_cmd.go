@@ -0,0 +1,560 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/username/time-tracker-bot/internal/calendar"
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/pkg/dateutil"
+	"go.uber.org/zap"
+)
+
+// newCalendar creates the production calendar: the provider chain from
+// calendar.providers, or the single calendar configured by calendar.type
+func newCalendar(cfg *config.Config) (calendar.Calendar, error) {
+	if len(cfg.Calendar.Providers) > 0 {
+		return newCalendarChain(cfg)
+	}
+
+	calType := cfg.Calendar.Type
+	if calType == "" {
+		calType = "isdayoff" // Default
+	}
+
+	switch calType {
+	case "isdayoff":
+		logger.Info("Using isdayoff.ru calendar API", zap.String("country", cfg.Calendar.GetCountry()))
+		return newIsDayOffCalendar(cfg), nil
+
+	case "production-calendar":
+		logger.Info("Using production-calendar.ru API (legacy)")
+		chain := calendar.NewChainCalendar([]calendar.ChainProvider{
+			{
+				Name: "production-calendar",
+				Calendar: calendar.NewProductionCalendar(
+					cfg.Calendar.APIURL,
+					cfg.Calendar.APIToken,
+					cfg.Calendar.Country,
+					cfg.Calendar.GetCacheTTL(),
+					logger,
+				),
+			},
+			{Name: "file", Calendar: newFileCalendar(cfg.Calendar.FallbackFile, calendar.NewStaticCalendar(cfg.TimeRules.GetTargetMinutesPerDay(), nil))},
+		}, cfg.Calendar.Breaker.Failures, cfg.Calendar.Breaker.GetCooldown(), logger)
+
+		// Load fallback calendar
+		if err := chain.Load(); err != nil {
+			logger.Warn("Failed to load fallback calendar, continuing with API only",
+				zap.Error(err))
+		}
+
+		return chain, nil
+
+	default:
+		return nil, fmt.Errorf("unknown calendar type: %s", calType)
+	}
+}
+
+// newCalendarChain creates the chain of calendar.providers in configured order
+func newCalendarChain(cfg *config.Config) (*calendar.ChainCalendar, error) {
+	providers := make([]calendar.ChainProvider, 0, len(cfg.Calendar.Providers))
+	for i := range cfg.Calendar.Providers {
+		providerCfg := &cfg.Calendar.Providers[i]
+		cal, err := newCalendarProvider(cfg, providerCfg)
+		if err != nil {
+			return nil, fmt.Errorf("calendar.providers[%d]: %w", i, err)
+		}
+		providers = append(providers, calendar.ChainProvider{
+			Name:     providerCfg.GetName(),
+			Calendar: cal,
+			Timeout:  providerCfg.GetTimeout(),
+		})
+	}
+
+	names := make([]string, len(providers))
+	for i, provider := range providers {
+		names[i] = provider.Name
+	}
+	logger.Info("Using calendar provider chain", zap.Strings("providers", names))
+
+	chain := calendar.NewChainCalendar(providers, cfg.Calendar.Breaker.Failures, cfg.Calendar.Breaker.GetCooldown(), logger)
+	if err := chain.Load(); err != nil {
+		logger.Warn("Some calendar providers failed to load", zap.Error(err))
+	}
+
+	return chain, nil
+}
+
+// newCalendarProvider creates one chain provider
+func newCalendarProvider(cfg *config.Config, providerCfg *config.CalendarProviderConfig) (calendar.Calendar, error) {
+	country := cfg.Calendar.GetCountry()
+	if providerCfg.Country != "" {
+		country = strings.ToLower(providerCfg.Country)
+	}
+	dayMinutes := cfg.TimeRules.GetTargetMinutesPerDay()
+
+	switch providerCfg.Type {
+	case "isdayoff":
+		cal := calendar.NewIsDayOffCalendar("", country, cfg.Calendar.GetCacheTTL(), dayMinutes, logger)
+		enableDiskCache(cfg, cal)
+		return cal, nil
+
+	case "xmlcalendar":
+		url := providerCfg.URL
+		if url == "" {
+			url = cfg.Calendar.FallbackURL
+		}
+		cal := calendar.NewXMLCalendar(url, country, cfg.Calendar.GetCacheTTL(), dayMinutes, logger)
+		enableDiskCache(cfg, cal)
+		return cal, nil
+
+	case "file":
+		// Days the file does not mention follow the weekday rules
+		defaults, err := newStaticCalendar(providerCfg, dayMinutes)
+		if err != nil {
+			return nil, err
+		}
+		return newFileCalendar(providerCfg.Path, defaults), nil
+
+	case "production-calendar":
+		return calendar.NewProductionCalendar(providerCfg.URL, providerCfg.APIToken, country, cfg.Calendar.GetCacheTTL(), logger), nil
+
+	case "static":
+		return newStaticCalendar(providerCfg, dayMinutes)
+
+	case "ics":
+		// Days the file does not mention follow the weekday rules
+		base, err := newStaticCalendar(providerCfg, dayMinutes)
+		if err != nil {
+			return nil, err
+		}
+		return calendar.NewICSCalendar(providerCfg.Path, base, providerCfg.AllDayOff, logger), nil
+
+	default:
+		return nil, fmt.Errorf("unknown provider type %q", providerCfg.Type)
+	}
+}
+
+func newFileCalendar(path string, defaults calendar.Calendar) *calendar.FileCalendar {
+	fc := calendar.NewFileCalendar(path, logger)
+	fc.SetDefaults(defaults)
+	return fc
+}
+
+func newStaticCalendar(providerCfg *config.CalendarProviderConfig, dayMinutes int) (*calendar.StaticCalendar, error) {
+	workdays := make([]time.Weekday, 0, len(providerCfg.Weekdays))
+	for _, name := range providerCfg.Weekdays {
+		day, err := dateutil.ParseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		workdays = append(workdays, day)
+	}
+	return calendar.NewStaticCalendar(dayMinutes, workdays), nil
+}
+
+// applyCalendarOverlays puts calendar.overlays on top of cal in order
+func applyCalendarOverlays(cfg *config.Config, cal calendar.Calendar) (calendar.Calendar, error) {
+	for i := range cfg.Calendar.Overlays {
+		overlayCfg := &cfg.Calendar.Overlays[i]
+		overlay := calendar.NewICSCalendar(overlayCfg.Path, cal, overlayCfg.AllDayOff, logger)
+		if err := overlay.Load(); err != nil {
+			return nil, fmt.Errorf("calendar.overlays[%d]: %w", i, err)
+		}
+		cal = overlay
+	}
+	return cal, nil
+}
+
+// newIsDayOffCalendar creates the isdayoff.ru calendar with its persistent cache
+func newIsDayOffCalendar(cfg *config.Config) *calendar.IsDayOffCalendar {
+	cal := calendar.NewIsDayOffCalendar(
+		cfg.Calendar.FallbackURL,
+		cfg.Calendar.GetCountry(),
+		cfg.Calendar.GetCacheTTL(),
+		cfg.TimeRules.GetTargetMinutesPerDay(),
+		logger,
+	)
+
+	enableDiskCache(cfg, cal)
+
+	return cal
+}
+
+// enableDiskCache attaches the persistent cache. A broken cache file is logged
+// and the calendar continues with memory caching only.
+func enableDiskCache(cfg *config.Config, cal *calendar.IsDayOffCalendar) {
+	if err := cal.EnableDiskCache(cfg.Calendar.GetCacheFile()); err != nil {
+		logger.Warn("Failed to open calendar cache, continuing without it",
+			zap.String("file", cfg.Calendar.GetCacheFile()),
+			zap.Error(err))
+	}
+}
+
+func calendarCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calendar",
+		Short: "Производственный календарь: кэш и диагностика",
+	}
+
+	cmd.AddCommand(calendarPrefetchCmd())
+	cmd.AddCommand(calendarDoctorCmd())
+	cmd.AddCommand(calendarValidateCmd())
+	cmd.AddCommand(calendarExportCmd())
+
+	return cmd
+}
+
+func calendarPrefetchCmd() *cobra.Command {
+	var year int
+
+	cmd := &cobra.Command{
+		Use:   "prefetch",
+		Short: "Скачать календарь на год в локальный кэш для работы офлайн",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			cfg.ExpandEnvVars()
+
+			if len(cfg.Calendar.Providers) > 0 {
+				return prefetchChain(cmd.Context(), cfg, year)
+			}
+			if cfg.Calendar.Type != "" && cfg.Calendar.Type != "isdayoff" {
+				return fmt.Errorf("prefetch is supported for calendar.type: isdayoff only")
+			}
+
+			cal := newIsDayOffCalendar(cfg)
+			summary, err := cal.Prefetch(cmd.Context(), year)
+			printPrefetchSummary(cfg, "isdayoff", cal.Country(), summary)
+			return err
+		},
+	}
+
+	cmd.Flags().IntVar(&year, "year", time.Now().Year(), "Year to download")
+
+	return cmd
+}
+
+// prefetchChain downloads the year for every isdayoff and xmlcalendar provider
+func prefetchChain(ctx context.Context, cfg *config.Config, year int) error {
+	chain, err := newCalendarChain(cfg)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, provider := range chain.Providers() {
+		cal, ok := provider.Calendar.(*calendar.IsDayOffCalendar)
+		if !ok {
+			continue
+		}
+		summary, err := cal.Prefetch(ctx, year)
+		printPrefetchSummary(cfg, provider.Name, cal.Country(), summary)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", provider.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// printPrefetchSummary prints what prefetch stored; country is the provider's own
+func printPrefetchSummary(cfg *config.Config, name, country string, summary *calendar.PrefetchSummary) {
+	if summary == nil {
+		return
+	}
+	fmt.Printf("Calendar %d, %s (%s) → %s\n", summary.Year, name, country, cfg.Calendar.GetCacheFile())
+	fmt.Printf("  isdayoff.ru months: %d/12\n", summary.APIMonths)
+	fmt.Printf("  xmlcalendar.ru year: %v\n", summary.FallbackYear)
+	for _, month := range summary.MissingMonths {
+		fmt.Printf("  ⚠️  %s: no data\n", month)
+	}
+}
+
+func calendarDoctorCmd() *cobra.Command {
+	var year int
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Сравнить источники календаря за год и показать расхождения",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			cfg.ExpandEnvVars()
+
+			cal, err := newCalendar(cfg)
+			if err != nil {
+				return err
+			}
+
+			var providers []calendar.ChainProvider
+			chain, isChain := cal.(*calendar.ChainCalendar)
+			if isChain {
+				providers = chain.Providers()
+			} else {
+				providers = []calendar.ChainProvider{{Name: cfg.Calendar.Type, Calendar: cal}}
+				if providers[0].Name == "" {
+					providers[0].Name = "isdayoff"
+				}
+			}
+
+			report := calendar.Diagnose(cmd.Context(), providers, year)
+
+			fmt.Printf("Calendar %d\n", report.Year)
+			for _, provider := range report.Providers {
+				fmt.Printf("  %-20s months: %2d/12  workdays: %3d  hours: %7.1f  (%s)\n",
+					provider.Name, provider.Months, provider.WorkDays,
+					float64(provider.WorkingMinutes)/60, provider.Took.Round(time.Millisecond))
+				for _, msg := range provider.Errors {
+					fmt.Printf("      ⚠️  %s\n", msg)
+				}
+			}
+
+			if isChain {
+				// Diagnose bypasses the breakers; run the year through the chain
+				// so health reflects real lookups
+				for month := time.January; month <= time.December; month++ {
+					_, _ = chain.GetMonthInfo(cmd.Context(), year, month)
+				}
+
+				fmt.Println("\nHealth:")
+				for _, health := range chain.Health() {
+					line := fmt.Sprintf("  %-20s %s", health.Name, health.State)
+					if health.LastError != nil {
+						line += fmt.Sprintf(" (%d failures, last: %v)", health.Failures, health.LastError)
+					}
+					fmt.Println(line)
+				}
+			}
+
+			if len(providers) < 2 {
+				return nil
+			}
+			if len(report.Disagreements) == 0 {
+				fmt.Println("\n✅ Providers agree on every day")
+				return nil
+			}
+
+			fmt.Printf("\nDisagreements: %d\n", len(report.Disagreements))
+			for _, disagreement := range report.Disagreements {
+				names := make([]string, 0, len(disagreement.Values))
+				for name := range disagreement.Values {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+
+				values := make([]string, len(names))
+				for i, name := range names {
+					values[i] = fmt.Sprintf("%s=%s", name, disagreement.Values[name])
+				}
+				fmt.Printf("  %s  %s\n", disagreement.Date.Format("2006-01-02 Mon"), strings.Join(values, "  "))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&year, "year", time.Now().Year(), "Year to check")
+
+	return cmd
+}
+
+func calendarValidateCmd() *cobra.Command {
+	var (
+		file string
+		year int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Проверить файл календаря: ошибки в строках и покрытие года",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			cfg.ExpandEnvVars()
+
+			dayMinutes := cfg.TimeRules.GetTargetMinutesPerDay()
+			var files []*calendar.FileCalendar
+			if file != "" {
+				files = append(files, newFileCalendar(file, calendar.NewStaticCalendar(dayMinutes, nil)))
+			}
+			for i := range cfg.Calendar.Providers {
+				providerCfg := &cfg.Calendar.Providers[i]
+				if file != "" || providerCfg.Type != "file" {
+					continue
+				}
+				defaults, err := newStaticCalendar(providerCfg, dayMinutes)
+				if err != nil {
+					return fmt.Errorf("calendar.providers[%d]: %w", i, err)
+				}
+				files = append(files, newFileCalendar(providerCfg.Path, defaults))
+			}
+			if file == "" && len(cfg.Calendar.Providers) == 0 && cfg.Calendar.FallbackFile != "" {
+				files = append(files, newFileCalendar(cfg.Calendar.FallbackFile, calendar.NewStaticCalendar(dayMinutes, nil)))
+			}
+			if len(files) == 0 {
+				return fmt.Errorf("no calendar file configured, use --file")
+			}
+
+			failed := 0
+			for _, fc := range files {
+				if !validateCalendarFile(cmd.Context(), fc, year) {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d calendar files have problems", failed, len(files))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Calendar file to check (default: file providers from config)")
+	cmd.Flags().IntVar(&year, "year", time.Now().Year(), "Year that must be covered")
+
+	return cmd
+}
+
+// validateCalendarFile prints the problems of a calendar file and its totals
+// for the year; false when the file has problems or does not cover the year
+func validateCalendarFile(ctx context.Context, fc *calendar.FileCalendar, year int) bool {
+	fmt.Printf("Calendar file %s\n", fc.Path())
+	if err := fc.Load(); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+		return false
+	}
+
+	ok := true
+	for _, issue := range fc.Issues() {
+		fmt.Printf("  ⚠️  %v\n", issue)
+		ok = false
+	}
+
+	days, err := calendar.YearDays(ctx, fc, year)
+	if err != nil {
+		fmt.Printf("  ❌ %d: %v\n", year, err)
+		return false
+	}
+
+	workdays, holidays, minutes := 0, 0, 0
+	for _, day := range days {
+		if day.IsWorkday {
+			workdays++
+			minutes += day.WorkingMinutes
+		} else if day.Type == calendar.DayTypeHoliday {
+			holidays++
+		}
+	}
+	fmt.Printf("  %d: workdays: %d  holidays: %d  hours: %.1f\n", year, workdays, holidays, float64(minutes)/60)
+
+	if ok {
+		fmt.Println("  ✅ No problems found")
+	}
+	return ok
+}
+
+func calendarExportCmd() *cobra.Command {
+	var (
+		year     int
+		format   string
+		output   string
+		provider string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Выгрузить календарь на год из любого источника в файл, JSON или ICS",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			cfg.ExpandEnvVars()
+
+			var cal calendar.Calendar
+			var rules calendar.Calendar = calendar.NewStaticCalendar(cfg.TimeRules.GetTargetMinutesPerDay(), nil)
+			if provider == "" {
+				cal, err = newCalendar(cfg)
+				if err == nil {
+					cal, err = applyCalendarOverlays(cfg, cal)
+				}
+			} else {
+				cal, rules, err = findCalendarProvider(cfg, provider)
+			}
+			if err != nil {
+				return err
+			}
+
+			days, err := calendar.YearDays(cmd.Context(), cal, year)
+			if err != nil {
+				return fmt.Errorf("failed to read calendar: %w", err)
+			}
+
+			out := os.Stdout
+			if output != "" && output != "-" {
+				out, err = os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer out.Close()
+			}
+
+			if err := calendar.Export(cmd.Context(), out, format, days, rules); err != nil {
+				return fmt.Errorf("failed to export calendar: %w", err)
+			}
+			if output != "" && output != "-" {
+				logger.Info("Calendar exported",
+					zap.Int("year", year),
+					zap.String("format", format),
+					zap.String("file", output))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&year, "year", time.Now().Year(), "Year to export")
+	cmd.Flags().StringVar(&format, "format", calendar.ExportFormatFile, "Output format: file, json or ics")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (default: stdout)")
+	cmd.Flags().StringVar(&provider, "provider", "", "Export a single provider of calendar.providers by name")
+
+	return cmd
+}
+
+// findCalendarProvider creates the named provider of calendar.providers and the
+// weekday rules it is measured against
+func findCalendarProvider(cfg *config.Config, name string) (calendar.Calendar, calendar.Calendar, error) {
+	dayMinutes := cfg.TimeRules.GetTargetMinutesPerDay()
+	for i := range cfg.Calendar.Providers {
+		providerCfg := &cfg.Calendar.Providers[i]
+		if providerCfg.GetName() != name {
+			continue
+		}
+
+		cal, err := newCalendarProvider(cfg, providerCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("calendar.providers[%d]: %w", i, err)
+		}
+		if loader, ok := cal.(calendar.Loader); ok {
+			if err := loader.Load(); err != nil {
+				return nil, nil, fmt.Errorf("calendar.providers[%d]: %w", i, err)
+			}
+		}
+		rules, err := newStaticCalendar(providerCfg, dayMinutes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("calendar.providers[%d]: %w", i, err)
+		}
+		return cal, rules, nil
+	}
+	return nil, nil, fmt.Errorf("no calendar provider named %q", name)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/pkg/dateutil"
+)
+
+func issuesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issues",
+		Short: "Задачи-кандидаты для списания времени",
+	}
+
+	cmd.AddCommand(issuesPreviewCmd())
+
+	return cmd
+}
+
+func issuesPreviewCmd() *cobra.Command {
+	var date string
+
+	cmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Показать, какие задачи вернул каждый источник кандидатов на дату",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			day := dateutil.Today()
+			if date != "" {
+				var err error
+				if day, err = parseDay(date); err != nil {
+					return err
+				}
+			}
+
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			cfg.ExpandEnvVars()
+
+			manager, err := initializeManager(cfg, nil)
+			if err != nil {
+				return err
+			}
+			manager.SetContext(cmd.Context())
+
+			preview := manager.PreviewCandidates(day)
+			fmt.Printf("Candidates for %s (history from %s)\n",
+				preview.Date.Format("2006-01-02"),
+				preview.From.Format("2006-01-02"))
+			for _, source := range preview.Sources {
+				fmt.Printf("\n%s: %d issue(s)\n", source.Name, len(source.Issues))
+				if source.Query != "" {
+					fmt.Printf("  query: %s\n", source.Query)
+				}
+				if source.Err != nil {
+					fmt.Printf("  ⚠️  %v\n", source.Err)
+					continue
+				}
+				if len(source.Issues) > 0 {
+					fmt.Printf("  %s\n", strings.Join(source.Issues, ", "))
+				}
+			}
+
+			fmt.Printf("\nTime of the day goes to %d issue(s) (fixed daily/weekly tasks excluded):\n", len(preview.Candidates))
+			if len(preview.Candidates) > 0 {
+				fmt.Printf("  %s\n", strings.Join(preview.Candidates, ", "))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&date, "date", "", "Date to preview, YYYY-MM-DD (default: today)")
+
+	return cmd
+}
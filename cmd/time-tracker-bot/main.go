@@ -1,16 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/username/time-tracker-bot/internal/activity"
 	"github.com/username/time-tracker-bot/internal/calendar"
 	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/internal/state"
+	"github.com/username/time-tracker-bot/internal/store"
 	"github.com/username/time-tracker-bot/internal/timemanager"
 	"github.com/username/time-tracker-bot/internal/tracker"
 	"github.com/username/time-tracker-bot/pkg/dateutil"
@@ -47,8 +56,16 @@ func main() {
 	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "config.yaml", "Config file path")
 
 	rootCmd.AddCommand(syncCmd())
-
-	if err := rootCmd.Execute(); err != nil {
+	rootCmd.AddCommand(historyCmd())
+	rootCmd.AddCommand(timeOffCmd())
+	rootCmd.AddCommand(calendarCmd())
+	rootCmd.AddCommand(issuesCmd())
+
+	// Interrupting a run cancels calendar lookups in flight
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -57,11 +74,12 @@ func main() {
 func syncCmd() *cobra.Command {
 	var dryRun bool
 	var teeOutput string
+	var lockWait time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "sync",
 		Short: "Backfill месяц и полностью заполнить сегодняшний день",
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			syncWriter = os.Stdout
 			if teeOutput != "" {
 				if err := os.MkdirAll(filepath.Dir(teeOutput), 0o755); err != nil {
@@ -89,12 +107,55 @@ func syncCmd() *cobra.Command {
 			}
 			cfg.ExpandEnvVars()
 
+			// Only one sync may touch state and Tracker at a time
+			lock, err := state.Acquire(cfg.State.GetLockFile(), lockWait)
+			if err != nil {
+				if errors.Is(err, state.ErrLocked) {
+					return fmt.Errorf("another sync is already running: %w; retry later or pass --wait", err)
+				}
+				return err
+			}
+			defer lock.Release()
+
+			st, err := openStore(cfg)
+			if err != nil {
+				return err
+			}
+			if st != nil {
+				defer st.Close()
+			}
+
 			// Initialize components
-			manager, err := initializeManager(cfg)
+			manager, err := initializeManager(cfg, st)
 			if err != nil {
 				return err
 			}
 
+			run := &store.RunRecord{
+				ID:        store.NewRunID(time.Now()),
+				Command:   "sync",
+				DryRun:    dryRun,
+				StartedAt: time.Now(),
+				Outcome:   store.OutcomeRunning,
+			}
+			saveRun(st, run)
+			manager.SetRun(st, run.ID)
+			manager.SetContext(cmd.Context())
+			defer func() {
+				run.FinishedAt = time.Now()
+				run.WorklogsCreated = manager.WorklogsCreated()
+				run.Outcome = store.OutcomeSuccess
+				if err != nil {
+					run.Outcome = store.OutcomeFailed
+					run.Error = err.Error()
+				}
+				if errors.Is(err, calendar.ErrStaleData) {
+					syncPrintln("\n⚠️  Only expired cached calendar data is available: no worklogs were changed.")
+					syncPrintln("   Retry when a calendar source is reachable; --dry-run works with cached data.")
+				}
+				saveRun(st, run)
+			}()
+
 			logger.Info("Starting full sync",
 				zap.Time("month_start", monthStart),
 				zap.Time("today", today),
@@ -109,6 +170,8 @@ func syncCmd() *cobra.Command {
 				return fmt.Errorf("normalization failed: %w", err)
 			}
 			if normalizeSummary != nil {
+				run.NormalizedDays = normalizeSummary.NormalizedDays
+				run.TrimmedMinutes = normalizeSummary.TotalMinutesTrimmed
 				syncPrintf("   • Processed %d days, normalized %d (%.1fh removed) in %s\n",
 					normalizeSummary.ProcessedDays,
 					normalizeSummary.NormalizedDays,
@@ -122,10 +185,14 @@ func syncCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("backfill failed: %w", err)
 			}
+			run.BackfilledDays = backfillResult.ProcessedDays
+			run.BackfillEntries = backfillResult.TotalEntries
+			run.BackfillMinutes = backfillResult.TotalMinutes
 			syncPrintf("   • Backfill processed %d day(s), %.1fh planned, took %s\n",
 				backfillResult.ProcessedDays,
 				backfillResult.TotalMinutes/60,
 				backfillResult.Duration.Round(time.Millisecond))
+			printUnknownStatuses(manager.UnknownStatuses())
 
 			monthlyStatus, err := manager.GetMonthlyStatus(monthStart, today)
 			if err != nil {
@@ -170,6 +237,9 @@ func syncCmd() *cobra.Command {
 							signLabel(diff),
 							math.Abs(diff)/60,
 							statusText)
+						if len(day.BySource) > 0 {
+							syncPrintf("               | %s\n", sourceBreakdown(day.BySource))
+						}
 					}
 					syncPrintln("\nLegend: '+' = лишнего залогировано, '-' = не хватает; Status=detailed text.")
 				}
@@ -177,9 +247,13 @@ func syncCmd() *cobra.Command {
 
 			if !dryRun {
 				syncPrintf("⏳ Step 3/3: filling today (%s)\n", today.Format("2006-01-02"))
-				if _, err := manager.DistributeTimeForDate(today, false, timelines); err != nil {
+				todayEntries, err := manager.DistributeTimeForDate(today, false, timelines)
+				if err != nil {
 					return fmt.Errorf("failed to distribute time: %w", err)
 				}
+				for _, entry := range todayEntries {
+					run.MinutesLoggedNow += entry.Minutes
+				}
 				syncPrintln("\n✅ Sync completed: month-to-date backfilled and today logged")
 			} else {
 				syncPrintln("\n[DRY RUN] No worklogs were created")
@@ -191,10 +265,325 @@ func syncCmd() *cobra.Command {
 
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview actions without creating worklogs")
 	cmd.Flags().StringVar(&teeOutput, "tee-output", "logs/cli-sync.log", "Mirror sync output to file (empty to disable)")
+	cmd.Flags().DurationVar(&lockWait, "wait", 0, "Wait up to this long for a running sync to finish (e.g. 10m) instead of exiting")
+
+	return cmd
+}
+
+func historyCmd() *cobra.Command {
+	var limit int
+	var runID string
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Показать историю прогонов sync и созданные ими worklog'и",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			cfg.ExpandEnvVars()
+
+			st, err := openStore(cfg)
+			if err != nil {
+				return err
+			}
+			if st == nil {
+				return fmt.Errorf("run history requires state.backend: bolt")
+			}
+			defer st.Close()
+
+			if runID != "" {
+				return printRun(st, runID)
+			}
+
+			runs, err := st.ListRuns(limit)
+			if err != nil {
+				return err
+			}
+			if len(runs) == 0 {
+				fmt.Println("No runs recorded yet")
+				return nil
+			}
+
+			fmt.Println("  Run                      | Started          | Took    | Outcome | Backfill     | Today  | Worklogs")
+			fmt.Println("--------------------------+------------------+---------+---------+--------------+--------+---------")
+			for _, run := range runs {
+				took := "-"
+				if !run.FinishedAt.IsZero() {
+					took = run.FinishedAt.Sub(run.StartedAt).Round(time.Second).String()
+				}
+				outcome := run.Outcome
+				if run.DryRun {
+					outcome += "*"
+				}
+				fmt.Printf("  %s | %s | %7s | %-7s | %3dd %6.1fh | %5.1fh | %d\n",
+					run.ID,
+					run.StartedAt.Local().Format("2006-01-02 15:04"),
+					took,
+					outcome,
+					run.BackfilledDays,
+					run.BackfillMinutes/60,
+					run.MinutesLoggedNow/60,
+					run.WorklogsCreated)
+			}
+			fmt.Println("\n'*' = dry run. Details: history --run <id>")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 20, "Number of most recent runs to show (0 = all)")
+	cmd.Flags().StringVar(&runID, "run", "", "Show details and created worklogs of a run")
 
 	return cmd
 }
 
+func printRun(st store.Store, runID string) error {
+	run, err := st.GetRun(runID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Run %s (%s)\n", run.ID, run.Command)
+	fmt.Println("═══════════════════════════════════════════════════════")
+	fmt.Printf("  Started:      %s\n", run.StartedAt.Local().Format(time.RFC3339))
+	if !run.FinishedAt.IsZero() {
+		fmt.Printf("  Finished:     %s\n", run.FinishedAt.Local().Format(time.RFC3339))
+	}
+	fmt.Printf("  Outcome:      %s\n", run.Outcome)
+	if run.Error != "" {
+		fmt.Printf("  Error:        %s\n", run.Error)
+	}
+	fmt.Printf("  Dry run:      %v\n", run.DryRun)
+	fmt.Printf("  Normalized:   %d day(s), %.1fh removed\n", run.NormalizedDays, run.TrimmedMinutes/60)
+	fmt.Printf("  Backfill:     %d day(s), %d entries, %.1fh\n", run.BackfilledDays, run.BackfillEntries, run.BackfillMinutes/60)
+	fmt.Printf("  Today:        %.1fh\n", run.MinutesLoggedNow/60)
+
+	worklogs, err := st.RunWorklogs(run.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n📝 Worklogs created: %d\n", len(worklogs))
+	for _, wl := range worklogs {
+		fmt.Printf("  %s %s  %-12s %6.0fm  #%s  %s\n",
+			wl.Date,
+			wl.Start.Local().Format("15:04"),
+			wl.IssueKey,
+			wl.Minutes,
+			wl.WorklogID,
+			wl.Comment)
+	}
+
+	return nil
+}
+
+// loadTimeOff merges time off ranges from config and the time off file
+func loadTimeOff(cfg *config.Config) ([]calendar.TimeOff, error) {
+	ranges, err := calendar.LoadTimeOffFile(cfg.TimeOff.GetFile())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range cfg.TimeOff.Ranges {
+		from, err := parseDay(r.From)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if r.To != "" {
+			if to, err = parseDay(r.To); err != nil {
+				return nil, err
+			}
+		}
+		ranges = append(ranges, calendar.TimeOff{From: from, To: to, Kind: r.Kind, Note: r.Note})
+	}
+
+	return ranges, nil
+}
+
+func parseDay(value string) (time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return day, nil
+}
+
+func timeOffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "timeoff",
+		Short: "Отпуска, больничные и отгулы (личный календарь поверх производственного)",
+	}
+
+	var from, to, kind, note string
+
+	addCmd := &cobra.Command{
+		Use:   "add",
+		Short: "Добавить период отсутствия",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			fromDay, err := parseDay(from)
+			if err != nil {
+				return err
+			}
+			toDay := fromDay
+			if to != "" {
+				if toDay, err = parseDay(to); err != nil {
+					return err
+				}
+			}
+			if toDay.Before(fromDay) {
+				return fmt.Errorf("--to is before --from")
+			}
+			switch kind {
+			case calendar.TimeOffVacation, calendar.TimeOffSick, calendar.TimeOffDayOff:
+			default:
+				return fmt.Errorf("--kind must be %s, %s or %s", calendar.TimeOffVacation, calendar.TimeOffSick, calendar.TimeOffDayOff)
+			}
+
+			// Overlaps are checked against the file and time_off.ranges from config
+			existing, err := loadTimeOff(cfg)
+			if err != nil {
+				return err
+			}
+			for _, r := range existing {
+				if !r.From.After(toDay) && !fromDay.After(r.To) {
+					return fmt.Errorf("overlaps existing %s %s..%s", r.Kind, r.From.Format("2006-01-02"), r.To.Format("2006-01-02"))
+				}
+			}
+
+			path := cfg.TimeOff.GetFile()
+			ranges, err := calendar.LoadTimeOffFile(path)
+			if err != nil {
+				return err
+			}
+			ranges = append(ranges, calendar.TimeOff{From: fromDay, To: toDay, Kind: kind, Note: note})
+			if err := calendar.SaveTimeOffFile(path, ranges); err != nil {
+				return err
+			}
+
+			fmt.Printf("✅ Added %s %s..%s\n", kind, fromDay.Format("2006-01-02"), toDay.Format("2006-01-02"))
+			if issue := cfg.TimeOff.AbsenceIssueFor(kind); issue != "" {
+				fmt.Printf("   Workdays in the range will be logged to %s\n", issue)
+			} else {
+				fmt.Println("   Workdays in the range will be skipped")
+			}
+			return nil
+		},
+	}
+	addCmd.Flags().StringVar(&from, "from", "", "First day off (YYYY-MM-DD)")
+	addCmd.Flags().StringVar(&to, "to", "", "Last day off, inclusive (YYYY-MM-DD, default = --from)")
+	addCmd.Flags().StringVar(&kind, "kind", calendar.TimeOffVacation, "vacation, sick or dayoff")
+	addCmd.Flags().StringVar(&note, "note", "", "Optional note, used as worklog comment when absence is logged")
+	_ = addCmd.MarkFlagRequired("from")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Показать периоды отсутствия",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			ranges, err := loadTimeOff(cfg)
+			if err != nil {
+				return err
+			}
+			if len(ranges) == 0 {
+				fmt.Println("No time off")
+				return nil
+			}
+
+			sort.Slice(ranges, func(i, j int) bool {
+				return ranges[i].From.Before(ranges[j].From)
+			})
+			for _, r := range ranges {
+				issue := cfg.TimeOff.AbsenceIssueFor(r.Kind)
+				if issue == "" {
+					issue = "skip"
+				}
+				fmt.Printf("  %s .. %s  %-8s → %-10s %s\n",
+					r.From.Format("2006-01-02"),
+					r.To.Format("2006-01-02"),
+					r.Kind,
+					issue,
+					r.Note)
+			}
+			return nil
+		},
+	}
+
+	var removeFrom string
+	removeCmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Удалить период отсутствия по дате начала",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			day, err := parseDay(removeFrom)
+			if err != nil {
+				return err
+			}
+
+			path := cfg.TimeOff.GetFile()
+			ranges, err := calendar.LoadTimeOffFile(path)
+			if err != nil {
+				return err
+			}
+
+			kept := ranges[:0]
+			removed := 0
+			for _, r := range ranges {
+				if r.From.Format("2006-01-02") == day.Format("2006-01-02") {
+					removed++
+					continue
+				}
+				kept = append(kept, r)
+			}
+			if removed == 0 {
+				return fmt.Errorf("no time off starting %s in %s (ranges from config are edited in the config file)", removeFrom, path)
+			}
+
+			if err := calendar.SaveTimeOffFile(path, kept); err != nil {
+				return err
+			}
+			fmt.Printf("✅ Removed time off starting %s\n", removeFrom)
+			return nil
+		},
+	}
+	removeCmd.Flags().StringVar(&removeFrom, "from", "", "First day of the range to remove (YYYY-MM-DD)")
+	_ = removeCmd.MarkFlagRequired("from")
+
+	cmd.AddCommand(addCmd, listCmd, removeCmd)
+	return cmd
+}
+
+func printUnknownStatuses(unknown map[string][]string) {
+	if len(unknown) == 0 {
+		return
+	}
+
+	queues := make([]string, 0, len(unknown))
+	for queue := range unknown {
+		queues = append(queues, queue)
+	}
+	sort.Strings(queues)
+
+	syncPrintln("⚠️  Unknown status keys in changelogs (add them to time_rules.active_statuses):")
+	for _, queue := range queues {
+		syncPrintf("   • %s: %s\n", queue, strings.Join(unknown[queue], ", "))
+	}
+}
+
 func syncPrintf(format string, a ...interface{}) {
 	if syncWriter == nil {
 		syncWriter = os.Stdout
@@ -209,7 +598,30 @@ func syncPrintln(a ...interface{}) {
 	fmt.Fprintln(syncWriter, a...)
 }
 
-func initializeManager(cfg *config.Config) (*timemanager.Manager, error) {
+// openStore opens the state database for the bolt backend; the json backend has none
+func openStore(cfg *config.Config) (store.Store, error) {
+	if cfg.State.GetBackend() != "bolt" {
+		return nil, nil
+	}
+
+	st, err := store.OpenBolt(cfg.State.GetDBFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state database: %w", err)
+	}
+	return st, nil
+}
+
+// saveRun persists the run record; history is best effort and never fails a sync
+func saveRun(st store.Store, run *store.RunRecord) {
+	if st == nil {
+		return
+	}
+	if err := st.SaveRun(run); err != nil {
+		logger.Warn("Failed to save run record", zap.String("run", run.ID), zap.Error(err))
+	}
+}
+
+func initializeManager(cfg *config.Config, st store.Store) (*timemanager.Manager, error) {
 	// Initialize IAM token manager
 	tokenManager := tracker.NewTokenManager(
 		cfg.IAM.GetRefreshInterval(),
@@ -232,49 +644,34 @@ func initializeManager(cfg *config.Config) (*timemanager.Manager, error) {
 	)
 
 	// Initialize calendar based on type
-	var cal calendar.Calendar
-
-	calType := cfg.Calendar.Type
-	if calType == "" {
-		calType = "isdayoff" // Default
+	cal, err := newCalendar(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cal, err = applyCalendarOverlays(cfg, cal)
+	if err != nil {
+		return nil, err
 	}
 
-	switch calType {
-	case "isdayoff":
-		logger.Info("Using isdayoff.ru calendar API")
-		cal = calendar.NewIsDayOffCalendar(
-			cfg.Calendar.FallbackURL,
-			cfg.Calendar.GetCacheTTL(),
-			logger,
-		)
-
-	case "production-calendar":
-		logger.Info("Using production-calendar.ru API (legacy)")
-		primaryCal := calendar.NewProductionCalendar(
-			cfg.Calendar.APIURL,
-			cfg.Calendar.APIToken,
-			cfg.Calendar.Country,
-			cfg.Calendar.GetCacheTTL(),
-			logger,
-		)
-
-		fallbackCal := calendar.NewFileCalendar(cfg.Calendar.FallbackFile, logger)
-		compositeCal := calendar.NewCompositeCalendar(primaryCal, fallbackCal, logger)
-
-		// Load fallback calendar
-		if err := compositeCal.LoadFallback(); err != nil {
-			logger.Warn("Failed to load fallback calendar, continuing with API only",
-				zap.Error(err))
-		}
-
-		cal = compositeCal
-
-	default:
-		return nil, fmt.Errorf("unknown calendar type: %s", calType)
+	// Personal time off on top of the production calendar
+	timeOff, err := loadTimeOff(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(timeOff) > 0 {
+		logger.Info("Personal time off loaded", zap.Int("ranges", len(timeOff)))
 	}
+	cal = calendar.NewOverlayCalendar(cal, timeOff, func(kind string) bool {
+		return cfg.TimeOff.AbsenceIssueFor(kind) != ""
+	}, logger)
 
 	// Initialize weekly state manager
-	weeklyState := timemanager.NewWeeklyStateManager(cfg.State.WeeklyScheduleFile, logger)
+	var weeklyState *timemanager.WeeklyStateManager
+	if st != nil {
+		weeklyState = timemanager.NewStoreWeeklyStateManager(st, cfg.State.WeeklyScheduleFile, cfg.State.GetWeeklyRetentionWeeks(), logger)
+	} else {
+		weeklyState = timemanager.NewWeeklyStateManager(cfg.State.WeeklyScheduleFile, cfg.State.GetWeeklyRetentionWeeks(), logger)
+	}
 	if err := weeklyState.Load(); err != nil {
 		return nil, fmt.Errorf("failed to load weekly state: %w", err)
 	}
@@ -282,6 +679,32 @@ func initializeManager(cfg *config.Config) (*timemanager.Manager, error) {
 	// Initialize time manager
 	manager := timemanager.NewManager(cfg, trackerClient, cal, weeklyState, logger)
 
+	// Meetings from the exported work calendar
+	if cfg.Meetings.File != "" {
+		meetings, err := timemanager.NewMeetingSource(cfg.Meetings, logger)
+		if err != nil {
+			return nil, err
+		}
+		if err := meetings.Load(); err != nil {
+			return nil, err
+		}
+		manager.SetMeetings(meetings)
+	}
+
+	// Git activity weights in-progress issues towards those with commits
+	if len(cfg.Activity.Repos) > 0 {
+		logger.Info("Git activity enabled",
+			zap.Strings("repos", cfg.Activity.Repos),
+			zap.String("weight_by", cfg.Activity.WeightBy))
+		manager.SetActivity(activity.NewGitSource(
+			cfg.Activity.Repos,
+			cfg.Activity.Authors,
+			cfg.Activity.WeightBy,
+			cfg.Activity.Queues,
+			logger,
+		))
+	}
+
 	return manager, nil
 }
 
@@ -342,6 +765,21 @@ func signLabel(value float64) string {
 	return "-"
 }
 
+// sourceBreakdown formats worked minutes by board or queue, e.g. "board:12 4.0h, support 2.0h"
+func sourceBreakdown(bySource map[string]float64) string {
+	names := make([]string, 0, len(bySource))
+	for name := range bySource {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s %.1fh", name, bySource[name]/60))
+	}
+	return strings.Join(parts, ", ")
+}
+
 func dayStatusLabel(diff float64) string {
 	if math.Abs(diff) < 0.01 {
 		return "ok"
@@ -0,0 +1,177 @@
+package activity
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/tracker"
+	"go.uber.org/zap"
+)
+
+// Weighting modes
+const (
+	WeightByCommits = "commits"
+	WeightByLines   = "lines"
+)
+
+// Field and record separators of the git log format
+const (
+	fieldSep  = "\x1f"
+	recordSep = "\x1e"
+)
+
+// Commit is a commit with the issue keys found in its message and source branch
+type Commit struct {
+	Hash    string
+	Keys    []string
+	Added   int
+	Deleted int
+}
+
+// GitSource finds the user's commits in local repositories and turns them into
+// per-issue activity. Keys come from commit messages and the branch the commit
+// was reached from.
+type GitSource struct {
+	repos    []string
+	authors  []string // empty = user.email of each repository
+	weightBy string
+	queues   map[string]bool // nil = any queue
+	logger   *zap.Logger
+}
+
+// NewGitSource creates a git activity source; weightBy is WeightByCommits or WeightByLines
+func NewGitSource(repos, authors []string, weightBy string, queues []string, logger *zap.Logger) *GitSource {
+	if weightBy == "" {
+		weightBy = WeightByCommits
+	}
+	source := &GitSource{
+		repos:    repos,
+		authors:  authors,
+		weightBy: weightBy,
+		logger:   logger,
+	}
+	if len(queues) > 0 {
+		source.queues = make(map[string]bool, len(queues))
+		for _, queue := range queues {
+			source.queues[strings.ToUpper(queue)] = true
+		}
+	}
+	return source
+}
+
+// Activity returns issue key → commit count or changed lines on the date.
+// Repositories that cannot be read are logged and skipped; an error is returned
+// only when no repository could be read.
+func (g *GitSource) Activity(date time.Time) (map[string]float64, error) {
+	activity := make(map[string]float64)
+	var lastErr error
+	read := 0
+
+	for _, repo := range g.repos {
+		commits, err := g.Commits(repo, date)
+		if err != nil {
+			g.logger.Warn("Failed to read git activity",
+				zap.String("repo", repo),
+				zap.Error(err))
+			lastErr = err
+			continue
+		}
+		read++
+
+		for _, commit := range commits {
+			for _, key := range commit.Keys {
+				if g.queues != nil && !g.queues[tracker.QueueOf(key)] {
+					continue
+				}
+				if g.weightBy == WeightByLines {
+					activity[key] += float64(commit.Added + commit.Deleted)
+				} else {
+					activity[key]++
+				}
+			}
+		}
+	}
+
+	if read == 0 && lastErr != nil {
+		return nil, fmt.Errorf("failed to read git activity: %w", lastErr)
+	}
+	return activity, nil
+}
+
+// Commits returns the user's commits in repo made on the date, across all branches
+func (g *GitSource) Commits(repo string, date time.Time) ([]Commit, error) {
+	authors := g.authors
+	if len(authors) == 0 {
+		email, err := runGit(repo, "config", "user.email")
+		if err != nil {
+			return nil, fmt.Errorf("no activity.authors and no user.email in %s: %w", repo, err)
+		}
+		authors = []string{strings.TrimSpace(email)}
+	}
+
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	args := []string{
+		"log", "--all", "--source", "--no-merges", "--numstat",
+		"--since=" + dayStart.Format(time.RFC3339),
+		"--until=" + dayStart.AddDate(0, 0, 1).Add(-time.Second).Format(time.RFC3339),
+		"--format=" + recordSep + "%H" + fieldSep + "%S" + fieldSep + "%B" + fieldSep,
+	}
+	for _, author := range authors {
+		args = append(args, "--author="+author)
+	}
+
+	out, err := runGit(repo, args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLog(out), nil
+}
+
+// parseLog parses git log output in the format built by Commits
+func parseLog(out string) []Commit {
+	var commits []Commit
+	for _, record := range strings.Split(out, recordSep) {
+		fields := strings.SplitN(record, fieldSep, 4)
+		if len(fields) < 4 {
+			continue
+		}
+
+		commit := Commit{
+			Hash: fields[0],
+			Keys: tracker.FindIssueKeys(fields[2] + "\n" + fields[1]),
+		}
+
+		// numstat lines: added<TAB>deleted<TAB>path ("-" for binary files)
+		scanner := bufio.NewScanner(strings.NewReader(fields[3]))
+		for scanner.Scan() {
+			parts := strings.SplitN(scanner.Text(), "\t", 3)
+			if len(parts) != 3 {
+				continue
+			}
+			added, _ := strconv.Atoi(parts[0])
+			deleted, _ := strconv.Atoi(parts[1])
+			commit.Added += added
+			commit.Deleted += deleted
+		}
+
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+func runGit(repo string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
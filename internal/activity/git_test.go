@@ -0,0 +1,94 @@
+package activity
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// gitRepo creates a repository and commits as the author at the given time
+type gitRepo struct {
+	t   *testing.T
+	dir string
+}
+
+func newGitRepo(t *testing.T) *gitRepo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := &gitRepo{t: t, dir: t.TempDir()}
+	repo.git(nil, "init", "-q", "-b", "main")
+	repo.git(nil, "config", "user.email", "me@example.com")
+	repo.git(nil, "config", "user.name", "Me")
+	return repo
+}
+
+func (r *gitRepo) git(env []string, args ...string) {
+	r.t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(), env...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		r.t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+func (r *gitRepo) commit(author string, at time.Time, file string, lines int, message string) {
+	r.t.Helper()
+	content := strings.Repeat("line\n", lines)
+	if err := os.WriteFile(filepath.Join(r.dir, file), []byte(content), 0o644); err != nil {
+		r.t.Fatal(err)
+	}
+	r.git(nil, "add", file)
+	date := at.Format(time.RFC3339)
+	r.git([]string{
+		"GIT_AUTHOR_NAME=" + author, "GIT_AUTHOR_EMAIL=" + author,
+		"GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_DATE=" + date,
+	}, "commit", "-q", "-m", message)
+}
+
+func TestGitSource_Activity(t *testing.T) {
+	repo := newGitRepo(t)
+	day := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+
+	repo.commit("me@example.com", day.Add(-2*time.Hour), "old.txt", 5, "PROJ-1 previous day")
+	repo.git(nil, "checkout", "-q", "-b", "feature/PROJ-2-search")
+	repo.commit("me@example.com", day.Add(10*time.Hour), "a.txt", 10, "Add search")
+	repo.commit("me@example.com", day.Add(11*time.Hour), "b.txt", 30, "PROJ-3: fix paging, refs OTHER-9")
+	repo.commit("colleague@example.com", day.Add(12*time.Hour), "c.txt", 100, "PROJ-3 colleague work")
+
+	commits := NewGitSource([]string{repo.dir}, nil, WeightByCommits, []string{"proj"}, zap.NewNop())
+	activity, err := commits.Activity(day)
+	if err != nil {
+		t.Fatalf("Activity() error = %v", err)
+	}
+	want := map[string]float64{"PROJ-2": 2, "PROJ-3": 1}
+	if len(activity) != len(want) || activity["PROJ-2"] != 2 || activity["PROJ-3"] != 1 {
+		t.Errorf("Activity(commits) = %v, want %v", activity, want)
+	}
+
+	lines := NewGitSource([]string{repo.dir}, []string{"me@example.com"}, WeightByLines, nil, zap.NewNop())
+	activity, err = lines.Activity(day)
+	if err != nil {
+		t.Fatalf("Activity() error = %v", err)
+	}
+	if activity["PROJ-2"] != 40 || activity["PROJ-3"] != 30 || activity["OTHER-9"] != 30 {
+		t.Errorf("Activity(lines) = %v, want PROJ-2=40 PROJ-3=30 OTHER-9=30", activity)
+	}
+}
+
+func TestGitSource_MissingRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	source := NewGitSource([]string{filepath.Join(t.TempDir(), "missing")}, []string{"me@example.com"}, "", nil, zap.NewNop())
+	if _, err := source.Activity(time.Now()); err == nil {
+		t.Error("Activity() error = nil for a missing repository")
+	}
+}
@@ -0,0 +1,95 @@
+package calendar
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultBreakerFailures = 3
+	defaultBreakerCooldown = 5 * time.Minute
+)
+
+// BreakerState is the state of a provider's circuit breaker
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"    // provider is used
+	BreakerOpen     BreakerState = "open"      // provider is skipped until the cooldown ends
+	BreakerHalfOpen BreakerState = "half-open" // cooldown ended, next call is a probe
+)
+
+// circuitBreaker stops calling a provider after consecutive failures and
+// lets one probe through once the cooldown has passed
+type circuitBreaker struct {
+	maxFailures int
+	cooldown    time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openedAt  time.Time
+	lastError error
+}
+
+func newCircuitBreaker(maxFailures int, cooldown time.Duration) *circuitBreaker {
+	if maxFailures <= 0 {
+		maxFailures = defaultBreakerFailures
+	}
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	return &circuitBreaker{
+		maxFailures: maxFailures,
+		cooldown:    cooldown,
+		now:         time.Now,
+	}
+}
+
+// State returns the current breaker state
+func (b *circuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state()
+}
+
+func (b *circuitBreaker) state() BreakerState {
+	if b.failures < b.maxFailures {
+		return BreakerClosed
+	}
+	if b.now().Sub(b.openedAt) < b.cooldown {
+		return BreakerOpen
+	}
+	return BreakerHalfOpen
+}
+
+// Allow reports whether the provider may be called now
+func (b *circuitBreaker) Allow() bool {
+	return b.State() != BreakerOpen
+}
+
+// Record updates the breaker with the outcome of a call. A failed probe
+// reopens the breaker for another cooldown.
+func (b *circuitBreaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		b.lastError = nil
+		return
+	}
+
+	b.failures++
+	b.lastError = err
+	if b.failures >= b.maxFailures {
+		b.openedAt = b.now()
+	}
+}
+
+// Stats returns consecutive failures and the last error
+func (b *circuitBreaker) Stats() (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures, b.lastError
+}
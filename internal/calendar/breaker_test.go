@@ -0,0 +1,40 @@
+package calendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2025, 11, 3, 12, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.Record(errors.New("boom"))
+	if b.State() != BreakerClosed {
+		t.Fatalf("State() after 1 failure = %s, want closed", b.State())
+	}
+
+	b.Record(errors.New("boom"))
+	if b.State() != BreakerOpen || b.Allow() {
+		t.Fatalf("State() after 2 failures = %s, want open", b.State())
+	}
+
+	now = now.Add(time.Minute)
+	if b.State() != BreakerHalfOpen || !b.Allow() {
+		t.Fatalf("State() after cooldown = %s, want half-open", b.State())
+	}
+
+	// A failed probe reopens for another cooldown
+	b.Record(errors.New("still down"))
+	if b.State() != BreakerOpen {
+		t.Fatalf("State() after failed probe = %s, want open", b.State())
+	}
+
+	now = now.Add(time.Minute)
+	b.Record(nil)
+	if failures, lastErr := b.Stats(); b.State() != BreakerClosed || failures != 0 || lastErr != nil {
+		t.Errorf("after success: state %s, %d failures, last error %v; want closed, 0, nil", b.State(), failures, lastErr)
+	}
+}
@@ -1,6 +1,11 @@
 package calendar
 
-import "time"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
 
 // DayType represents the type of day
 type DayType int
@@ -12,34 +17,143 @@ const (
 	DayTypeShortened
 )
 
+// dayTypeNames are the names of day types in calendar files and exports
+var dayTypeNames = map[DayType]string{
+	DayTypeWorkday:   "workday",
+	DayTypeWeekend:   "weekend",
+	DayTypeHoliday:   "holiday",
+	DayTypeShortened: "shortened",
+}
+
+// String returns the day type name used in calendar files
+func (t DayType) String() string {
+	if name, ok := dayTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("DayType(%d)", int(t))
+}
+
+// ParseDayType parses a day type name
+func ParseDayType(name string) (DayType, error) {
+	for dayType, typeName := range dayTypeNames {
+		if typeName == name {
+			return dayType, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day type %q", name)
+}
+
+const (
+	// DefaultDayMinutes is a full workday of a 40-hour week
+	DefaultDayMinutes = 8 * 60
+
+	// shortenedDayCutMinutes is how much shorter a pre-holiday day is
+	shortenedDayCutMinutes = 60
+)
+
 // DayInfo represents information about a specific day
 type DayInfo struct {
-	Date         time.Time
-	Type         DayType
-	WorkingHours int
-	IsWorkday    bool
-	Note         string
+	Date           time.Time
+	Type           DayType
+	WorkingMinutes int
+	IsWorkday      bool
+	Note           string
+	Stale          bool // From an expired cache: every source was unavailable
+}
+
+// WorkingHours returns the working time of the day in hours
+func (d DayInfo) WorkingHours() float64 {
+	return float64(d.WorkingMinutes) / 60
 }
 
 // MonthInfo represents calendar information for a month
 type MonthInfo struct {
-	Year         int
-	Month        time.Month
-	WorkingHours int // Total working hours in the month
-	WorkDays     int
-	Weekends     int
-	Holidays     int
-	Days         []DayInfo
+	Year           int
+	Month          time.Month
+	WorkingMinutes int // Total working time in the month
+	WorkDays       int
+	Weekends       int
+	Holidays       int
+	Days           []DayInfo
+	Stale          bool // From an expired cache: every source was unavailable
+}
+
+// WorkingHours returns the total working time of the month in hours
+func (m *MonthInfo) WorkingHours() float64 {
+	return float64(m.WorkingMinutes) / 60
+}
+
+// Day returns a copy of the info for the date, or false when the month does not list it.
+// Complete months are indexed directly by day of month; partial ones are scanned.
+func (m *MonthInfo) Day(date time.Time) (*DayInfo, bool) {
+	if i := date.Day() - 1; i < len(m.Days) && sameDay(m.Days[i].Date, date) {
+		day := m.Days[i]
+		return &day, true
+	}
+
+	for _, day := range m.Days {
+		if sameDay(day.Date, date) {
+			return &day, true
+		}
+	}
+	return nil, false
+}
+
+// recount recomputes the month totals from its days
+func (m *MonthInfo) recount() {
+	m.WorkingMinutes, m.WorkDays, m.Weekends, m.Holidays = 0, 0, 0, 0
+	for _, day := range m.Days {
+		switch {
+		case day.IsWorkday:
+			m.WorkDays++
+			m.WorkingMinutes += day.WorkingMinutes
+		case day.Type == DayTypeWeekend:
+			m.Weekends++
+		case day.Type == DayTypeHoliday:
+			m.Holidays++
+		}
+	}
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
+// markStale flags the month and its days as served from an expired cache
+func (m *MonthInfo) markStale() {
+	m.Stale = true
+	for i := range m.Days {
+		m.Days[i].Stale = true
+	}
+}
+
+var (
+	// ErrDayNotCovered means the provider has no data for the date (a year
+	// missing from a file, a month not published yet); another provider may have it
+	ErrDayNotCovered = errors.New("day not covered by calendar")
+
+	// ErrProviderUnavailable means the provider could not be reached or returned
+	// an unusable answer; another provider or a later retry may succeed
+	ErrProviderUnavailable = errors.New("calendar provider unavailable")
+
+	// ErrStaleData means the only calendar data available came from an expired cache
+	ErrStaleData = errors.New("calendar data is stale")
+)
+
+// IsFallbackError reports whether another provider should be asked after err.
+// Other errors (cancelled context, invalid request) end the lookup.
+func IsFallbackError(err error) bool {
+	return errors.Is(err, ErrDayNotCovered) || errors.Is(err, ErrProviderUnavailable)
 }
 
 // Calendar interface for checking working days
 type Calendar interface {
-	// IsWorkday checks if the given date is a working day
-	IsWorkday(date time.Time) (bool, int, error)
+	// IsWorkday checks if the given date is a working day and returns its working minutes
+	IsWorkday(ctx context.Context, date time.Time) (bool, int, error)
 
 	// GetMonthInfo returns calendar info for the entire month
-	GetMonthInfo(year int, month time.Month) (*MonthInfo, error)
+	GetMonthInfo(ctx context.Context, year int, month time.Month) (*MonthInfo, error)
 
 	// GetDayInfo returns detailed info for a specific day
-	GetDayInfo(date time.Time) (*DayInfo, error)
+	GetDayInfo(ctx context.Context, date time.Time) (*DayInfo, error)
 }
@@ -0,0 +1,228 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Loader is implemented by providers that read local data before use (file, ICS)
+type Loader interface {
+	Load() error
+}
+
+// ChainProvider is one calendar in a ChainCalendar
+type ChainProvider struct {
+	Name     string
+	Calendar Calendar
+	Timeout  time.Duration // 0 = no limit
+}
+
+// ProviderHealth is the health of a chain provider
+type ProviderHealth struct {
+	Name      string
+	State     BreakerState
+	Failures  int
+	LastError error
+}
+
+type chainLink struct {
+	ChainProvider
+	breaker *circuitBreaker
+}
+
+// ChainCalendar implements Calendar over an ordered list of providers.
+// Each call goes to the first provider that answers. The next provider is asked
+// only when one is unavailable or does not cover the date; any other error ends
+// the lookup. Providers that keep being unavailable are skipped by their circuit
+// breaker until the cooldown ends.
+type ChainCalendar struct {
+	links  []*chainLink
+	logger *zap.Logger
+}
+
+// NewChainCalendar creates a chain; maxFailures and cooldown configure every
+// provider's circuit breaker (0 = defaults: 3 failures, 5 minutes)
+func NewChainCalendar(providers []ChainProvider, maxFailures int, cooldown time.Duration, logger *zap.Logger) *ChainCalendar {
+	links := make([]*chainLink, 0, len(providers))
+	for _, provider := range providers {
+		links = append(links, &chainLink{
+			ChainProvider: provider,
+			breaker:       newCircuitBreaker(maxFailures, cooldown),
+		})
+	}
+	return &ChainCalendar{links: links, logger: logger}
+}
+
+// Load loads every provider that implements Loader. Providers that fail to load
+// stay in the chain and are reported by their errors at lookup time.
+func (cc *ChainCalendar) Load() error {
+	var errs []error
+	for _, link := range cc.links {
+		loader, ok := link.Calendar.(Loader)
+		if !ok {
+			continue
+		}
+		if err := loader.Load(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", link.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Providers returns the chain's providers in order
+func (cc *ChainCalendar) Providers() []ChainProvider {
+	providers := make([]ChainProvider, len(cc.links))
+	for i, link := range cc.links {
+		providers[i] = link.ChainProvider
+	}
+	return providers
+}
+
+// Health reports the circuit breaker state of every provider
+func (cc *ChainCalendar) Health() []ProviderHealth {
+	health := make([]ProviderHealth, len(cc.links))
+	for i, link := range cc.links {
+		failures, lastErr := link.breaker.Stats()
+		health[i] = ProviderHealth{
+			Name:      link.Name,
+			State:     link.breaker.State(),
+			Failures:  failures,
+			LastError: lastErr,
+		}
+	}
+	return health
+}
+
+// IsWorkday checks if the given date is a working day
+func (cc *ChainCalendar) IsWorkday(ctx context.Context, date time.Time) (bool, int, error) {
+	day, err := cc.GetDayInfo(ctx, date)
+	if err != nil {
+		return false, 0, err
+	}
+	return day.IsWorkday, day.WorkingMinutes, nil
+}
+
+// GetMonthInfo returns calendar info for the entire month
+func (cc *ChainCalendar) GetMonthInfo(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
+	return firstAnswer(ctx, cc, fmt.Sprintf("%d-%02d", year, month), func(ctx context.Context, cal Calendar) (*MonthInfo, error) {
+		return cal.GetMonthInfo(ctx, year, month)
+	})
+}
+
+// GetDayInfo returns detailed info for a specific day
+func (cc *ChainCalendar) GetDayInfo(ctx context.Context, date time.Time) (*DayInfo, error) {
+	return firstAnswer(ctx, cc, date.Format("2006-01-02"), func(ctx context.Context, cal Calendar) (*DayInfo, error) {
+		return cal.GetDayInfo(ctx, date)
+	})
+}
+
+// firstAnswer calls providers in order until one succeeds or fails with an
+// error that is not a fallback error. A stale answer is kept while later
+// providers are asked for fresh data, and returned only if none has it.
+func firstAnswer[T any](ctx context.Context, cc *ChainCalendar, what string, call func(context.Context, Calendar) (T, error)) (T, error) {
+	var zero, stale T
+	var staleFrom string
+	var errs []error
+	for _, link := range cc.links {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		if !link.breaker.Allow() {
+			cc.logger.Debug("Calendar provider skipped, circuit open",
+				zap.String("provider", link.Name))
+			errs = append(errs, fmt.Errorf("%s: %w: circuit open", link.Name, ErrProviderUnavailable))
+			continue
+		}
+
+		cal := link.Calendar
+		result, err := callWithTimeout(ctx, link.Timeout, func(ctx context.Context) (T, error) { return call(ctx, cal) })
+
+		// Only unavailability counts against the provider: not covering a date is an answer
+		if errors.Is(err, ErrProviderUnavailable) {
+			link.breaker.Record(err)
+		} else {
+			link.breaker.Record(nil)
+		}
+
+		if err == nil && !isStale(result) {
+			return result, nil
+		}
+		if err == nil {
+			if staleFrom == "" {
+				stale, staleFrom = result, link.Name
+			}
+			continue
+		}
+		if !IsFallbackError(err) {
+			return zero, fmt.Errorf("%s: %w", link.Name, err)
+		}
+
+		cc.logger.Warn("Calendar provider failed, trying next",
+			zap.String("provider", link.Name),
+			zap.String("period", what),
+			zap.Error(err))
+		errs = append(errs, fmt.Errorf("%s: %w", link.Name, err))
+	}
+
+	if staleFrom != "" {
+		cc.logger.Warn("No calendar provider has fresh data, using stale",
+			zap.String("provider", staleFrom),
+			zap.String("period", what))
+		return stale, nil
+	}
+	if len(errs) == 0 {
+		return zero, fmt.Errorf("no calendar providers configured")
+	}
+	return zero, fmt.Errorf("all calendar providers failed for %s: %w", what, errors.Join(errs...))
+}
+
+func isStale(value any) bool {
+	switch v := value.(type) {
+	case *MonthInfo:
+		return v != nil && v.Stale
+	case *DayInfo:
+		return v != nil && v.Stale
+	}
+	return false
+}
+
+type callResult[T any] struct {
+	value T
+	err   error
+}
+
+// callWithTimeout runs call and gives up after timeout with ErrProviderUnavailable.
+// The call gets a context cancelled at the timeout; one that ignores it keeps
+// running in the background and its result is dropped.
+func callWithTimeout[T any](ctx context.Context, timeout time.Duration, call func(context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return call(ctx)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan callResult[T], 1)
+	go func() {
+		value, err := call(callCtx)
+		done <- callResult[T]{value: value, err: err}
+	}()
+
+	var zero T
+	select {
+	case result := <-done:
+		if result.err != nil && ctx.Err() == nil && callCtx.Err() != nil {
+			return zero, fmt.Errorf("%w: timed out after %s", ErrProviderUnavailable, timeout)
+		}
+		return result.value, result.err
+	case <-callCtx.Done():
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		return zero, fmt.Errorf("%w: timed out after %s", ErrProviderUnavailable, timeout)
+	}
+}
@@ -0,0 +1,177 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// stubCalendar delegates to a StaticCalendar unless err is set, counting calls
+type stubCalendar struct {
+	*StaticCalendar
+	err   error
+	delay time.Duration
+	calls int
+}
+
+func newStubCalendar(err error) *stubCalendar {
+	return &stubCalendar{StaticCalendar: NewStaticCalendar(0, nil), err: err}
+}
+
+func (s *stubCalendar) GetMonthInfo(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
+	s.calls++
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.StaticCalendar.GetMonthInfo(ctx, year, month)
+}
+
+func (s *stubCalendar) GetDayInfo(ctx context.Context, date time.Time) (*DayInfo, error) {
+	s.calls++
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.StaticCalendar.GetDayInfo(ctx, date)
+}
+
+func TestChainCalendar_FallsThrough(t *testing.T) {
+	broken := newStubCalendar(fmt.Errorf("%w: connection refused", ErrProviderUnavailable))
+	working := newStubCalendar(nil)
+	chain := NewChainCalendar([]ChainProvider{
+		{Name: "broken", Calendar: broken},
+		{Name: "working", Calendar: working},
+	}, 2, time.Hour, zap.NewNop())
+
+	for i := 0; i < 3; i++ {
+		isWorkday, minutes, err := chain.IsWorkday(context.Background(), time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC))
+		if err != nil || !isWorkday || minutes != DefaultDayMinutes {
+			t.Fatalf("IsWorkday() = %v, %d, %v; want true, %d, nil", isWorkday, minutes, err, DefaultDayMinutes)
+		}
+	}
+
+	// The broken provider is skipped once its circuit opens
+	if broken.calls != 2 {
+		t.Errorf("broken provider calls = %d, want 2", broken.calls)
+	}
+	health := chain.Health()
+	if health[0].State != BreakerOpen || health[0].Failures != 2 || health[0].LastError == nil {
+		t.Errorf("broken health = %+v, want open with 2 failures", health[0])
+	}
+	if health[1].State != BreakerClosed {
+		t.Errorf("working health = %+v, want closed", health[1])
+	}
+}
+
+func TestChainCalendar_AllFail(t *testing.T) {
+	chain := NewChainCalendar([]ChainProvider{
+		{Name: "a", Calendar: newStubCalendar(fmt.Errorf("%w: a down", ErrProviderUnavailable))},
+		{Name: "b", Calendar: newStubCalendar(fmt.Errorf("%w: b has no 2025", ErrDayNotCovered))},
+	}, 0, 0, zap.NewNop())
+
+	_, err := chain.GetMonthInfo(context.Background(), 2025, time.November)
+	if !errors.Is(err, ErrProviderUnavailable) || !errors.Is(err, ErrDayNotCovered) {
+		t.Fatalf("GetMonthInfo() error = %v, want both provider errors", err)
+	}
+}
+
+func TestChainCalendar_FallbackErrorsOnly(t *testing.T) {
+	uncovered := newStubCalendar(fmt.Errorf("%w: no 2025", ErrDayNotCovered))
+	invalid := newStubCalendar(errors.New("invalid month"))
+	last := newStubCalendar(nil)
+	chain := NewChainCalendar([]ChainProvider{
+		{Name: "uncovered", Calendar: uncovered},
+		{Name: "invalid", Calendar: invalid},
+		{Name: "last", Calendar: last},
+	}, 1, time.Hour, zap.NewNop())
+
+	if _, err := chain.GetMonthInfo(context.Background(), 2025, time.November); err == nil || IsFallbackError(err) {
+		t.Fatalf("GetMonthInfo() error = %v, want the invalid provider's error", err)
+	}
+	if last.calls != 0 {
+		t.Errorf("last provider calls = %d, want 0 after a non-fallback error", last.calls)
+	}
+
+	// Not covering a date is an answer, not a failure: the circuit stays closed
+	if health := chain.Health(); health[0].State != BreakerClosed {
+		t.Errorf("uncovered health = %+v, want closed", health[0])
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := chain.GetMonthInfo(ctx, 2025, time.November); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetMonthInfo() with cancelled context error = %v, want context.Canceled", err)
+	}
+}
+
+func TestChainCalendar_StaleOnlyAsLastResort(t *testing.T) {
+	stale := &staleCalendar{NewStaticCalendar(420, nil)}
+	down := newStubCalendar(fmt.Errorf("%w: down", ErrProviderUnavailable))
+
+	chain := NewChainCalendar([]ChainProvider{
+		{Name: "stale", Calendar: stale},
+		{Name: "fresh", Calendar: NewStaticCalendar(0, nil)},
+	}, 0, 0, zap.NewNop())
+	monthInfo, err := chain.GetMonthInfo(context.Background(), 2025, time.November)
+	if err != nil || monthInfo.Stale || monthInfo.WorkingMinutes != 20*DefaultDayMinutes {
+		t.Errorf("GetMonthInfo() = %+v, %v; want the fresh provider's month", monthInfo, err)
+	}
+
+	chain = NewChainCalendar([]ChainProvider{
+		{Name: "stale", Calendar: stale},
+		{Name: "down", Calendar: down},
+	}, 0, 0, zap.NewNop())
+	day, err := chain.GetDayInfo(context.Background(), time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil || !day.Stale || day.WorkingMinutes != 420 {
+		t.Errorf("GetDayInfo() = %+v, %v; want the stale day", day, err)
+	}
+}
+
+// staleCalendar answers like its StaticCalendar but marks everything stale
+type staleCalendar struct {
+	*StaticCalendar
+}
+
+func (s *staleCalendar) GetMonthInfo(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
+	monthInfo, err := s.StaticCalendar.GetMonthInfo(ctx, year, month)
+	if err != nil {
+		return nil, err
+	}
+	monthInfo.markStale()
+	return monthInfo, nil
+}
+
+func (s *staleCalendar) GetDayInfo(ctx context.Context, date time.Time) (*DayInfo, error) {
+	day, err := s.StaticCalendar.GetDayInfo(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	day.Stale = true
+	return day, nil
+}
+
+func TestChainCalendar_Timeout(t *testing.T) {
+	slow := newStubCalendar(nil)
+	slow.delay = 200 * time.Millisecond
+	chain := NewChainCalendar([]ChainProvider{
+		{Name: "slow", Calendar: slow, Timeout: 10 * time.Millisecond},
+		{Name: "static", Calendar: NewStaticCalendar(0, nil)},
+	}, 0, 0, zap.NewNop())
+
+	started := time.Now()
+	monthInfo, err := chain.GetMonthInfo(context.Background(), 2025, time.November)
+	if err != nil {
+		t.Fatalf("GetMonthInfo() error = %v", err)
+	}
+	if took := time.Since(started); took >= slow.delay {
+		t.Errorf("GetMonthInfo() took %s, want the slow provider abandoned", took)
+	}
+	if monthInfo.WorkDays != 20 {
+		t.Errorf("WorkDays = %d, want 20", monthInfo.WorkDays)
+	}
+}
@@ -0,0 +1,131 @@
+package calendar
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/state"
+)
+
+// diskCache persists raw calendar data between runs, so repeated CLI invocations
+// and offline runs do not need the network. A nil *diskCache is a valid no-op cache.
+type diskCache struct {
+	path string
+	mu   sync.Mutex
+	data diskCacheFile
+}
+
+// diskCacheFile is the on-disk format. Raw responses are stored rather than parsed
+// MonthInfo, so a changed workday length applies to cached data too.
+type diskCacheFile struct {
+	Months map[string]cachedBulkMonth `json:"months"` // "ru/2025-11" → isdayoff.ru bulk data
+	Years  map[string]cachedXMLYear   `json:"years"`  // "ru/2025" → xmlcalendar.ru year
+}
+
+type cachedBulkMonth struct {
+	Data      string    `json:"data"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+type cachedXMLYear struct {
+	Data      *xmlCalendarYear `json:"data"`
+	FetchedAt time.Time        `json:"fetched_at"`
+}
+
+// openDiskCache loads the cache file; a missing file is an empty cache
+func openDiskCache(path string) (*diskCache, error) {
+	cache := &diskCache{
+		path: path,
+		data: diskCacheFile{
+			Months: make(map[string]cachedBulkMonth),
+			Years:  make(map[string]cachedXMLYear),
+		},
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, fmt.Errorf("failed to read calendar cache: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, &cache.data); err != nil {
+		return nil, fmt.Errorf("failed to parse calendar cache: %w", err)
+	}
+	if cache.data.Months == nil {
+		cache.data.Months = make(map[string]cachedBulkMonth)
+	}
+	if cache.data.Years == nil {
+		cache.data.Years = make(map[string]cachedXMLYear)
+	}
+
+	return cache, nil
+}
+
+// month returns cached bulk data; stale reports whether it is older than ttl
+func (dc *diskCache) month(key string, ttl time.Duration) (data string, stale bool, ok bool) {
+	if dc == nil {
+		return "", false, false
+	}
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	entry, ok := dc.data.Months[key]
+	if !ok {
+		return "", false, false
+	}
+	return entry.Data, time.Since(entry.FetchedAt) >= ttl, true
+}
+
+// year returns a cached xmlcalendar year; stale reports whether it is older than ttl
+func (dc *diskCache) year(key string, ttl time.Duration) (data *xmlCalendarYear, stale bool, ok bool) {
+	if dc == nil {
+		return nil, false, false
+	}
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	entry, ok := dc.data.Years[key]
+	if !ok || entry.Data == nil {
+		return nil, false, false
+	}
+	return entry.Data, time.Since(entry.FetchedAt) >= ttl, true
+}
+
+func (dc *diskCache) putMonth(key, data string) error {
+	if dc == nil {
+		return nil
+	}
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	dc.data.Months[key] = cachedBulkMonth{Data: data, FetchedAt: time.Now()}
+	return dc.save()
+}
+
+func (dc *diskCache) putYear(key string, data *xmlCalendarYear) error {
+	if dc == nil {
+		return nil
+	}
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	dc.data.Years[key] = cachedXMLYear{Data: data, FetchedAt: time.Now()}
+	return dc.save()
+}
+
+// save writes the whole cache atomically; callers hold mu
+func (dc *diskCache) save() error {
+	raw, err := json.MarshalIndent(dc.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal calendar cache: %w", err)
+	}
+	if err := state.WriteFileAtomic(dc.path, raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write calendar cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// offlineTransport fails every request, as if the network were down
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("network is unreachable")
+}
+
+func newOfflineCalendar(t *testing.T, cachePath string, ttl time.Duration) *IsDayOffCalendar {
+	t.Helper()
+	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/{country}/{year}/calendar.json", "ru", ttl, DefaultDayMinutes, zap.NewNop())
+	cal.httpClient.Transport = offlineTransport{}
+	if err := cal.EnableDiskCache(cachePath); err != nil {
+		t.Fatalf("EnableDiskCache() error = %v", err)
+	}
+	return cal
+}
+
+func TestIsDayOffCalendar_DiskCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calendar_cache.json")
+
+	cache, err := openDiskCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.putMonth("ru/2025-11", "211100011000001100000110000011"); err != nil {
+		t.Fatalf("putMonth() error = %v", err)
+	}
+
+	// A new process reads the month from disk without touching the network
+	cal := newOfflineCalendar(t, path, 24*time.Hour)
+	monthInfo, err := cal.GetMonthInfo(context.Background(), 2025, time.November)
+	if err != nil {
+		t.Fatalf("GetMonthInfo() error = %v", err)
+	}
+	if monthInfo.WorkDays != 19 {
+		t.Errorf("WorkDays = %d, want 19", monthInfo.WorkDays)
+	}
+
+	// Other countries do not share the entry
+	other := NewIsDayOffCalendar("https://xmlcalendar.ru/data/{country}/{year}/calendar.json", "kz", 24*time.Hour, DefaultDayMinutes, zap.NewNop())
+	other.httpClient.Transport = offlineTransport{}
+	if err := other.EnableDiskCache(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.GetMonthInfo(context.Background(), 2025, time.November); err == nil {
+		t.Error("GetMonthInfo() for kz succeeded from the ru cache entry")
+	}
+}
+
+func TestIsDayOffCalendar_DiskCache_ExpiredUsedOffline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calendar_cache.json")
+
+	cache, err := openDiskCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.putMonth("ru/2025-11", "211100011000001100000110000011"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every entry is expired immediately, and both sources are unreachable
+	cal := newOfflineCalendar(t, path, time.Nanosecond)
+	dayInfo, err := cal.GetDayInfo(context.Background(), time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetDayInfo() error = %v", err)
+	}
+	if dayInfo.Type != DayTypeShortened || dayInfo.WorkingMinutes != 420 || !dayInfo.Stale {
+		t.Errorf("GetDayInfo() = %v / %d min / stale %v, want shortened / 420 / stale",
+			dayInfo.Type, dayInfo.WorkingMinutes, dayInfo.Stale)
+	}
+}
+
+func TestIsDayOffCalendar_PrefetchOffline(t *testing.T) {
+	cal := newOfflineCalendar(t, filepath.Join(t.TempDir(), "calendar_cache.json"), 24*time.Hour)
+
+	summary, err := cal.Prefetch(context.Background(), 2026)
+	if err == nil {
+		t.Fatal("Prefetch() offline returned no error")
+	}
+	if summary == nil || len(summary.MissingMonths) != 12 || summary.APIMonths != 0 {
+		t.Errorf("Prefetch() summary = %+v, want 12 missing months", summary)
+	}
+}
+
+// bulkTransport serves a fixed isdayoff.ru bulk response and counts requests
+type bulkTransport struct {
+	data     string
+	requests int
+}
+
+func (b *bulkTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	b.requests++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(b.data)),
+		Request:    req,
+	}, nil
+}
+
+func TestIsDayOffCalendar_OneRequestPerMonth(t *testing.T) {
+	transport := &bulkTransport{data: "211100011000001100000110000011"}
+	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/{country}/{year}/calendar.json", "ru", 24*time.Hour, DefaultDayMinutes, zap.NewNop())
+	cal.httpClient.Transport = transport
+
+	for day := 1; day <= 30; day++ {
+		if _, _, err := cal.IsWorkday(context.Background(), time.Date(2025, 11, day, 0, 0, 0, 0, time.UTC)); err != nil {
+			t.Fatalf("IsWorkday(Nov %d) error = %v", day, err)
+		}
+	}
+	if _, err := cal.GetMonthInfo(context.Background(), 2025, time.November); err != nil {
+		t.Fatal(err)
+	}
+
+	if transport.requests != 1 {
+		t.Errorf("requests = %d, want 1", transport.requests)
+	}
+}
@@ -0,0 +1,106 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DoctorReport compares calendar providers over a year
+type DoctorReport struct {
+	Year          int
+	Providers     []ProviderReport
+	Disagreements []Disagreement
+}
+
+// ProviderReport summarises what one provider returned for the year
+type ProviderReport struct {
+	Name           string
+	Months         int // months the provider answered
+	WorkDays       int
+	WorkingMinutes int
+	Took           time.Duration
+	Errors         []string
+}
+
+// Disagreement is a day on which providers answered differently
+type Disagreement struct {
+	Date   time.Time
+	Values map[string]string // provider name → "work 480m" or "off"
+}
+
+// Diagnose queries every provider directly, bypassing the chain and its circuit
+// breakers, for each month of the year and reports days where they disagree on
+// whether the day is worked or on its length.
+func Diagnose(ctx context.Context, providers []ChainProvider, year int) *DoctorReport {
+	report := &DoctorReport{Year: year}
+	answers := make(map[string]map[string]string) // date → provider → value
+	dates := make(map[string]time.Time)
+
+	for _, provider := range providers {
+		providerReport := ProviderReport{Name: provider.Name}
+		started := time.Now()
+
+		for month := time.January; month <= time.December; month++ {
+			cal := provider.Calendar
+			monthInfo, err := callWithTimeout(ctx, provider.Timeout, func(ctx context.Context) (*MonthInfo, error) {
+				return cal.GetMonthInfo(ctx, year, month)
+			})
+			if err != nil {
+				providerReport.Errors = append(providerReport.Errors, fmt.Sprintf("%s: %v", month, err))
+				continue
+			}
+
+			providerReport.Months++
+			for _, day := range monthInfo.Days {
+				key := day.Date.Format("2006-01-02")
+				if answers[key] == nil {
+					answers[key] = make(map[string]string)
+					dates[key] = day.Date
+				}
+				answers[key][provider.Name] = describeDay(day)
+				if day.IsWorkday {
+					providerReport.WorkDays++
+					providerReport.WorkingMinutes += day.WorkingMinutes
+				}
+			}
+		}
+
+		providerReport.Took = time.Since(started)
+		report.Providers = append(report.Providers, providerReport)
+	}
+
+	for key, values := range answers {
+		if len(values) < 2 || !disagree(values) {
+			continue
+		}
+		report.Disagreements = append(report.Disagreements, Disagreement{Date: dates[key], Values: values})
+	}
+	sort.Slice(report.Disagreements, func(i, j int) bool {
+		return report.Disagreements[i].Date.Before(report.Disagreements[j].Date)
+	})
+
+	return report
+}
+
+func describeDay(day DayInfo) string {
+	if !day.IsWorkday {
+		return "off"
+	}
+	return fmt.Sprintf("work %dm", day.WorkingMinutes)
+}
+
+func disagree(values map[string]string) bool {
+	first := ""
+	for _, value := range values {
+		if first == "" {
+			first = value
+			continue
+		}
+		if value != first {
+			return true
+		}
+	}
+	return false
+}
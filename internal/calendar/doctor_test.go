@@ -0,0 +1,35 @@
+package calendar
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDiagnose(t *testing.T) {
+	sixDays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}
+	report := Diagnose(context.Background(), []ChainProvider{
+		{Name: "five", Calendar: NewStaticCalendar(0, nil)},
+		{Name: "six", Calendar: NewStaticCalendar(0, sixDays)},
+		{Name: "broken", Calendar: newStubCalendar(ErrProviderUnavailable)},
+	}, 2025)
+
+	if len(report.Providers) != 3 {
+		t.Fatalf("Providers = %d, want 3", len(report.Providers))
+	}
+	if five := report.Providers[0]; five.Months != 12 || five.WorkDays != 261 || five.WorkingMinutes != 261*DefaultDayMinutes {
+		t.Errorf("five = %+v, want 12 months, 261 workdays", five)
+	}
+	if broken := report.Providers[2]; broken.Months != 0 || len(broken.Errors) != 12 {
+		t.Errorf("broken = %d months, %d errors; want 0, 12", broken.Months, len(broken.Errors))
+	}
+
+	// 2025 has 52 Saturdays
+	if len(report.Disagreements) != 52 {
+		t.Fatalf("Disagreements = %d, want 52", len(report.Disagreements))
+	}
+	first := report.Disagreements[0]
+	if !first.Date.Equal(time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC)) || first.Values["five"] != "off" || first.Values["six"] != "work 480m" {
+		t.Errorf("first disagreement = %s %v, want 2025-01-04 five=off six=work 480m", first.Date.Format("2006-01-02"), first.Values)
+	}
+}
@@ -0,0 +1,164 @@
+package calendar
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Export formats
+const (
+	ExportFormatFile = "file"
+	ExportFormatJSON = "json"
+	ExportFormatICS  = "ics"
+)
+
+// YearDays returns every day of the year from the calendar
+func YearDays(ctx context.Context, cal Calendar, year int) ([]DayInfo, error) {
+	var days []DayInfo
+	for month := time.January; month <= time.December; month++ {
+		monthInfo, err := cal.GetMonthInfo(ctx, year, month)
+		if err != nil {
+			return nil, fmt.Errorf("%d-%02d: %w", year, month, err)
+		}
+		days = append(days, monthInfo.Days...)
+	}
+	return days, nil
+}
+
+// Export writes days in the given format. The file format lists every day, so
+// the result needs no defaults; ICS lists only the days that differ from rules,
+// as all-day events ICSCalendar reads back over the same rules.
+func Export(ctx context.Context, w io.Writer, format string, days []DayInfo, rules Calendar) error {
+	switch format {
+	case ExportFormatFile:
+		return exportFile(w, days)
+	case ExportFormatJSON:
+		return exportJSON(w, days)
+	case ExportFormatICS:
+		return exportICS(ctx, w, days, rules)
+	default:
+		return fmt.Errorf("unknown export format %q (expected %s, %s or %s)",
+			format, ExportFormatFile, ExportFormatJSON, ExportFormatICS)
+	}
+}
+
+func exportFile(w io.Writer, days []DayInfo) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "# YYYY-MM-DD type working_time [note]")
+	for _, day := range days {
+		line := fmt.Sprintf("%s %s %s", day.Date.Format("2006-01-02"), fileDayType(day), formatWorkingTime(day.WorkingMinutes))
+		if day.Note != "" {
+			line += " " + day.Note
+		}
+		fmt.Fprintln(bw, line)
+	}
+	return bw.Flush()
+}
+
+// fileDayType maps a day to a type the file format knows
+func fileDayType(day DayInfo) DayType {
+	if day.Type == DayTypeTimeOff {
+		return DayTypeHoliday
+	}
+	return day.Type
+}
+
+// formatWorkingTime formats minutes as ParseWorkingTime reads them: whole hours
+// as "8", anything else as "432m"
+func formatWorkingTime(minutes int) string {
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%d", minutes/60)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+type jsonDay struct {
+	Date    string `json:"date"`
+	Type    string `json:"type"`
+	Minutes int    `json:"minutes"`
+	Workday bool   `json:"workday"`
+	Note    string `json:"note,omitempty"`
+}
+
+func exportJSON(w io.Writer, days []DayInfo) error {
+	result := make([]jsonDay, len(days))
+	for i, day := range days {
+		result[i] = jsonDay{
+			Date:    day.Date.Format("2006-01-02"),
+			Type:    day.Type.String(),
+			Minutes: day.WorkingMinutes,
+			Workday: day.IsWorkday,
+			Note:    day.Note,
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+func exportICS(ctx context.Context, w io.Writer, days []DayInfo, rules Calendar) error {
+	bw := bufio.NewWriter(w)
+	writeICSLine(bw, "BEGIN:VCALENDAR")
+	writeICSLine(bw, "VERSION:2.0")
+	writeICSLine(bw, "PRODID:-//time-tracker-bot//calendar export//EN")
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	for _, day := range days {
+		expected, err := rules.GetDayInfo(ctx, day.Date)
+		if err != nil {
+			return err
+		}
+		if day.IsWorkday == expected.IsWorkday && day.WorkingMinutes == expected.WorkingMinutes {
+			continue
+		}
+
+		date := day.Date.Format("20060102")
+		summary := day.Note
+		if summary == "" {
+			summary = day.Type.String()
+		}
+
+		writeICSLine(bw, "BEGIN:VEVENT")
+		writeICSLine(bw, "UID:"+date+"@time-tracker-bot")
+		writeICSLine(bw, "DTSTAMP:"+stamp)
+		writeICSLine(bw, "DTSTART;VALUE=DATE:"+date)
+		writeICSLine(bw, "DTEND;VALUE=DATE:"+day.Date.AddDate(0, 0, 1).Format("20060102"))
+		writeICSLine(bw, "SUMMARY:"+escapeICSText(summary))
+		if !day.IsWorkday {
+			writeICSLine(bw, "CATEGORIES:Holiday")
+		}
+		writeICSLine(bw, ICSWorkingTimeProperty+":"+fmt.Sprintf("%dm", day.WorkingMinutes))
+		writeICSLine(bw, "END:VEVENT")
+	}
+
+	writeICSLine(bw, "END:VCALENDAR")
+	return bw.Flush()
+}
+
+// writeICSLine writes a content line folded at 75 octets (RFC 5545, 3.1)
+func writeICSLine(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74 // the leading space counts
+	}
+	w.WriteString(line + "\r\n")
+}
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func escapeICSText(value string) string {
+	return icsTextEscaper.Replace(value)
+}
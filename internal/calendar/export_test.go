@@ -0,0 +1,138 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// exportSource is a 2026 calendar with a holiday, a shortened day and a working Saturday
+func exportSource(t *testing.T) *FileCalendar {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "source.txt")
+	content := `2026-01-01 holiday 0 Новый год, выходной
+2026-02-20 shortened 7 Предпраздничный день
+2026-02-28 workday 8 Рабочая суббота
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fc := NewFileCalendar(path, zap.NewNop())
+	if err := fc.Load(); err != nil {
+		t.Fatal(err)
+	}
+	return fc
+}
+
+func TestExport_FileRoundTrip(t *testing.T) {
+	source := exportSource(t)
+	days, err := YearDays(context.Background(), source, 2026)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 365 {
+		t.Fatalf("YearDays(context.Background(), ) = %d days, want 365", len(days))
+	}
+
+	var buf bytes.Buffer
+	if err := Export(context.Background(), &buf, ExportFormatFile, days, nil); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "export.txt")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A complete file needs no defaults: make them disagree to prove it
+	exported := NewFileCalendar(path, zap.NewNop())
+	exported.SetDefaults(NewStaticCalendar(60, []time.Weekday{time.Sunday}))
+	if err := exported.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if issues := exported.Issues(); len(issues) != 0 {
+		t.Fatalf("Issues() = %v, want none", issues)
+	}
+	assertSameYear(t, source, exported, 2026)
+}
+
+func TestExport_ICSRoundTrip(t *testing.T) {
+	source := exportSource(t)
+	days, err := YearDays(context.Background(), source, 2026)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules := NewStaticCalendar(0, nil)
+	var buf bytes.Buffer
+	if err := Export(context.Background(), &buf, ExportFormatICS, days, rules); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(buf.String(), "BEGIN:VEVENT"); got != 3 {
+		t.Errorf("exported %d events, want 3 exceptions", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "export.ics")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	exported := NewICSCalendar(path, rules, false, zap.NewNop())
+	if err := exported.Load(); err != nil {
+		t.Fatal(err)
+	}
+	assertSameYear(t, source, exported, 2026)
+
+	day, err := exported.GetDayInfo(context.Background(), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil || day.Note != "Новый год, выходной" {
+		t.Errorf("GetDayInfo(Jan 1) note = %v, %v; want the escaped summary back", day, err)
+	}
+}
+
+func TestExport_JSON(t *testing.T) {
+	days, err := YearDays(context.Background(), exportSource(t), 2026)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Export(context.Background(), &buf, ExportFormatJSON, days[:1], nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []jsonDay
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := jsonDay{Date: "2026-01-01", Type: "holiday", Note: "Новый год, выходной"}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("JSON = %+v, want [%+v]", got, want)
+	}
+
+	if err := Export(context.Background(), &buf, "xml", days, nil); err == nil {
+		t.Error("Export(context.Background(), ) with unknown format: expected error")
+	}
+}
+
+func assertSameYear(t *testing.T, want, got Calendar, year int) {
+	t.Helper()
+	for month := time.January; month <= time.December; month++ {
+		wantMonth, err := want.GetMonthInfo(context.Background(), year, month)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotMonth, err := got.GetMonthInfo(context.Background(), year, month)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotMonth.WorkDays != wantMonth.WorkDays || gotMonth.WorkingMinutes != wantMonth.WorkingMinutes {
+			t.Errorf("%s: %d days / %d min, want %d / %d", month,
+				gotMonth.WorkDays, gotMonth.WorkingMinutes, wantMonth.WorkDays, wantMonth.WorkingMinutes)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package calendar
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -11,23 +13,59 @@ import (
 	"go.uber.org/zap"
 )
 
-// FileCalendar implements Calendar interface using a local text file
+// FileCalendar implements Calendar interface using a local text file.
+// The file lists exceptions only: days it does not mention follow the weekday
+// rules of the defaults calendar, within the years the file covers.
 type FileCalendar struct {
 	filePath string
 	logger   *zap.Logger
-	data     map[string]*MonthInfo // key: "YYYY-MM"
+	days     map[string]DayInfo // key: "YYYY-MM-DD"
+	years    map[int]bool       // years with at least one listed day
+	defaults Calendar
+	issues   []FileIssue
 }
 
-// NewFileCalendar creates a new FileCalendar instance
+// FileIssue is a problem found on a line of a calendar file
+type FileIssue struct {
+	Line int
+	Text string
+	Err  error
+}
+
+func (i FileIssue) Error() string {
+	return fmt.Sprintf("line %d: %v: %q", i.Line, i.Err, i.Text)
+}
+
+// NewFileCalendar creates a new FileCalendar instance; unlisted days default
+// to 8-hour workdays Monday to Friday (see SetDefaults)
 func NewFileCalendar(filePath string, logger *zap.Logger) *FileCalendar {
 	return &FileCalendar{
 		filePath: filePath,
 		logger:   logger,
-		data:     make(map[string]*MonthInfo),
+		days:     make(map[string]DayInfo),
+		years:    make(map[int]bool),
+		defaults: NewStaticCalendar(0, nil),
 	}
 }
 
-// Load loads calendar data from file
+// SetDefaults sets the calendar used for days the file does not list
+func (fc *FileCalendar) SetDefaults(defaults Calendar) {
+	fc.defaults = defaults
+}
+
+// Path returns the calendar file path
+func (fc *FileCalendar) Path() string {
+	return fc.filePath
+}
+
+// Issues returns problems found by the last Load: unparsable lines, duplicate
+// dates and working time that contradicts the day type
+func (fc *FileCalendar) Issues() []FileIssue {
+	return fc.issues
+}
+
+// Load loads calendar data from file. Bad lines are skipped with a warning and
+// reported by Issues.
 func (fc *FileCalendar) Load() error {
 	file, err := os.Open(fc.filePath)
 	if err != nil {
@@ -35,160 +73,162 @@ func (fc *FileCalendar) Load() error {
 	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	var currentMonth *MonthInfo
+	days := make(map[string]DayInfo)
+	years := make(map[int]bool)
+	var issues []FileIssue
 
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
 	for scanner.Scan() {
+		lineNo++
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
 
-		// Parse line
-		// Format: YYYY-MM-DD type working_hours [note]
-		// Example: 2025-01-01 holiday 0 Новогодние каникулы
-		parts := strings.SplitN(line, " ", 4)
-		if len(parts) < 3 {
-			fc.logger.Warn("Invalid line format", zap.String("line", line))
-			continue
-		}
-
-		dateStr := parts[0]
-		typeStr := parts[1]
-		hoursStr := parts[2]
-		note := ""
-		if len(parts) == 4 {
-			note = parts[3]
+		day, err := parseFileLine(line)
+		if err == nil {
+			key := day.Date.Format("2006-01-02")
+			if _, duplicate := days[key]; duplicate {
+				err = fmt.Errorf("duplicate date %s", key)
+			} else {
+				days[key] = day
+				years[day.Date.Year()] = true
+				err = checkWorkingTime(day)
+			}
 		}
-
-		// Parse date
-		date, err := time.Parse("2006-01-02", dateStr)
 		if err != nil {
-			fc.logger.Warn("Failed to parse date", zap.String("date", dateStr), zap.Error(err))
-			continue
+			fc.logger.Warn("Invalid calendar line",
+				zap.String("file", fc.filePath),
+				zap.Int("line", lineNo),
+				zap.String("text", line),
+				zap.Error(err))
+			issues = append(issues, FileIssue{Line: lineNo, Text: line, Err: err})
 		}
+	}
 
-		// Parse working hours
-		hours, err := strconv.Atoi(hoursStr)
-		if err != nil {
-			fc.logger.Warn("Failed to parse hours", zap.String("hours", hoursStr), zap.Error(err))
-			continue
-		}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading calendar file: %w", err)
+	}
 
-		// Determine day type
-		var dayType DayType
-		isWorkday := false
-		switch typeStr {
-		case "workday":
-			dayType = DayTypeWorkday
-			isWorkday = true
-		case "weekend":
-			dayType = DayTypeWeekend
-		case "holiday":
-			dayType = DayTypeHoliday
-		case "shortened":
-			dayType = DayTypeShortened
-			isWorkday = true
-		default:
-			fc.logger.Warn("Unknown day type", zap.String("type", typeStr))
-			continue
-		}
+	fc.days, fc.years, fc.issues = days, years, issues
 
-		// Create month key
-		monthKey := fmt.Sprintf("%d-%02d", date.Year(), date.Month())
+	fc.logger.Info("Calendar file loaded",
+		zap.String("file", fc.filePath),
+		zap.Int("days", len(days)),
+		zap.Int("issues", len(issues)))
 
-		// Get or create month info
-		if currentMonth == nil || fc.getMonthKey(currentMonth) != monthKey {
-			if currentMonth != nil {
-				// Save previous month
-				fc.data[fc.getMonthKey(currentMonth)] = currentMonth
-			}
+	return nil
+}
 
-			currentMonth = &MonthInfo{
-				Year:  date.Year(),
-				Month: date.Month(),
-				Days:  []DayInfo{},
-			}
-		}
+// parseFileLine parses "YYYY-MM-DD type working_time [note]".
+// Working time is hours ("8", "7.2", "6,5") or minutes ("432m").
+// Example: 2025-01-01 holiday 0 Новогодние каникулы
+func parseFileLine(line string) (DayInfo, error) {
+	parts := strings.SplitN(line, " ", 4)
+	if len(parts) < 3 {
+		return DayInfo{}, fmt.Errorf("expected: YYYY-MM-DD type working_time [note]")
+	}
 
-		// Add day
-		dayInfo := DayInfo{
-			Date:         date,
-			Type:         dayType,
-			WorkingHours: hours,
-			IsWorkday:    isWorkday,
-			Note:         note,
-		}
-		currentMonth.Days = append(currentMonth.Days, dayInfo)
-
-		// Update statistics
-		if isWorkday {
-			currentMonth.WorkDays++
-			currentMonth.WorkingHours += hours
-		} else if dayType == DayTypeWeekend {
-			currentMonth.Weekends++
-		} else if dayType == DayTypeHoliday {
-			currentMonth.Holidays++
-		}
+	date, err := time.Parse("2006-01-02", parts[0])
+	if err != nil {
+		return DayInfo{}, fmt.Errorf("invalid date %q", parts[0])
 	}
 
-	// Save last month
-	if currentMonth != nil {
-		fc.data[fc.getMonthKey(currentMonth)] = currentMonth
+	minutes, err := ParseWorkingTime(parts[2])
+	if err != nil {
+		return DayInfo{}, err
 	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading calendar file: %w", err)
+	dayType, err := ParseDayType(parts[1])
+	if err != nil {
+		return DayInfo{}, err
 	}
 
-	fc.logger.Info("Calendar file loaded",
-		zap.String("file", fc.filePath),
-		zap.Int("months", len(fc.data)))
+	day := DayInfo{
+		Date:           date,
+		Type:           dayType,
+		WorkingMinutes: minutes,
+		IsWorkday:      dayType == DayTypeWorkday || dayType == DayTypeShortened,
+	}
+	if len(parts) == 4 {
+		day.Note = parts[3]
+	}
+	return day, nil
+}
 
+// checkWorkingTime reports working time that contradicts the day type
+func checkWorkingTime(day DayInfo) error {
+	if day.IsWorkday && day.WorkingMinutes == 0 {
+		return fmt.Errorf("%s without working time", day.Type)
+	}
+	if !day.IsWorkday && day.WorkingMinutes > 0 {
+		return fmt.Errorf("%s with working time", day.Type)
+	}
 	return nil
 }
 
 // IsWorkday checks if the given date is a working day
-func (fc *FileCalendar) IsWorkday(date time.Time) (bool, int, error) {
-	dayInfo, err := fc.GetDayInfo(date)
+func (fc *FileCalendar) IsWorkday(ctx context.Context, date time.Time) (bool, int, error) {
+	dayInfo, err := fc.GetDayInfo(ctx, date)
 	if err != nil {
 		return false, 0, err
 	}
 
-	return dayInfo.IsWorkday, dayInfo.WorkingHours, nil
+	return dayInfo.IsWorkday, dayInfo.WorkingMinutes, nil
 }
 
 // GetMonthInfo returns calendar info for the entire month
-func (fc *FileCalendar) GetMonthInfo(year int, month time.Month) (*MonthInfo, error) {
-	monthKey := fmt.Sprintf("%d-%02d", year, month)
-
-	monthInfo, ok := fc.data[monthKey]
-	if !ok {
-		return nil, fmt.Errorf("month not found in calendar: %s", monthKey)
+func (fc *FileCalendar) GetMonthInfo(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
+	if !fc.years[year] {
+		return nil, fmt.Errorf("%w: year %d is not in %s", ErrDayNotCovered, year, fc.filePath)
 	}
 
-	return monthInfo, nil
-}
-
-// GetDayInfo returns detailed info for a specific day
-func (fc *FileCalendar) GetDayInfo(date time.Time) (*DayInfo, error) {
-	monthInfo, err := fc.GetMonthInfo(date.Year(), date.Month())
+	monthInfo, err := fc.defaults.GetMonthInfo(ctx, year, month)
 	if err != nil {
 		return nil, err
 	}
 
-	// Find the specific day
-	for _, day := range monthInfo.Days {
-		if day.Date.Year() == date.Year() &&
-			day.Date.Month() == date.Month() &&
-			day.Date.Day() == date.Day() {
-			return &day, nil
+	result := *monthInfo
+	result.Days = make([]DayInfo, len(monthInfo.Days))
+	for i, day := range monthInfo.Days {
+		if listed, ok := fc.days[day.Date.Format("2006-01-02")]; ok {
+			day = listed
 		}
+		result.Days[i] = day
 	}
+	result.recount()
 
-	return nil, fmt.Errorf("day not found in calendar: %s", date.Format("2006-01-02"))
+	return &result, nil
 }
 
-func (fc *FileCalendar) getMonthKey(month *MonthInfo) string {
-	return fmt.Sprintf("%d-%02d", month.Year, month.Month)
+// GetDayInfo returns detailed info for a specific day
+func (fc *FileCalendar) GetDayInfo(ctx context.Context, date time.Time) (*DayInfo, error) {
+	if !fc.years[date.Year()] {
+		return nil, fmt.Errorf("%w: year %d is not in %s", ErrDayNotCovered, date.Year(), fc.filePath)
+	}
+
+	if day, ok := fc.days[date.Format("2006-01-02")]; ok {
+		return &day, nil
+	}
+	return fc.defaults.GetDayInfo(ctx, date)
+}
+
+// ParseWorkingTime parses a calendar working time into minutes.
+// Plain numbers are hours and may be fractional ("7.2", "6,5"); an "m" suffix means minutes ("432m").
+func ParseWorkingTime(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasSuffix(value, "m") {
+		minutes, err := strconv.Atoi(strings.TrimSuffix(value, "m"))
+		if err != nil || minutes < 0 {
+			return 0, fmt.Errorf("invalid working minutes %q", value)
+		}
+		return minutes, nil
+	}
+
+	hours, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+	if err != nil || hours < 0 || hours > 24 {
+		return 0, fmt.Errorf("invalid working hours %q", value)
+	}
+	return int(math.Round(hours * 60)), nil
 }
@@ -0,0 +1,127 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestParseWorkingTime(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"8", 480, false},
+		{"7.2", 432, false},
+		{"6,5", 390, false},
+		{"432m", 432, false},
+		{"0", 0, false},
+		{"25", 0, true},
+		{"-1", 0, true},
+		{"abc", 0, true},
+		{"7.5m", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseWorkingTime(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWorkingTime(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseWorkingTime(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileCalendar_FractionalHours(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calendar.txt")
+	content := `# 36-hour week
+2025-12-29 workday 7.2
+2025-12-30 shortened 372m Предпраздничный день
+2025-12-31 holiday 0 Новый год
+2025-12-01 workday 8
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fc := NewFileCalendar(path, zap.NewNop())
+	if err := fc.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	isWorkday, minutes, err := fc.IsWorkday(context.Background(), time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC))
+	if err != nil || !isWorkday || minutes != 432 {
+		t.Errorf("IsWorkday(Dec 29) = %v, %d, %v; want true, 432", isWorkday, minutes, err)
+	}
+
+	info, err := fc.GetMonthInfo(context.Background(), 2025, time.December)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 23 weekdays minus the holiday; unlisted ones are 8 hours
+	wantMinutes := 432 + 372 + 20*480
+	if info.WorkDays != 22 || info.WorkingMinutes != wantMinutes || info.Holidays != 1 {
+		t.Errorf("GetMonthInfo() = %d days / %d min / %d holidays, want 22 / %d / 1",
+			info.WorkDays, info.WorkingMinutes, info.Holidays, wantMinutes)
+	}
+}
+
+func TestFileCalendar_ExceptionsOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calendar.txt")
+	content := `2026-01-01 holiday 0 Новый год
+2026-01-10 workday 8 Рабочая суббота
+not a date
+2026-01-01 holiday 0
+2026-01-12 workday 0
+2026-01-13 weekend 8
+2026-01-14 vacation 0
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fc := NewFileCalendar(path, zap.NewNop())
+	fc.SetDefaults(NewStaticCalendar(420, nil))
+	if err := fc.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if issues := fc.Issues(); len(issues) != 5 {
+		t.Errorf("Issues() = %v, want 5", issues)
+	}
+
+	tests := []struct {
+		date        time.Time
+		wantWorkday bool
+		wantMinutes int
+	}{
+		{time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), false, 0},   // listed holiday
+		{time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), true, 420},  // unlisted Friday
+		{time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC), false, 0},   // unlisted Saturday
+		{time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), true, 480}, // listed Saturday
+	}
+	for _, tt := range tests {
+		isWorkday, minutes, err := fc.IsWorkday(context.Background(), tt.date)
+		if err != nil || isWorkday != tt.wantWorkday || minutes != tt.wantMinutes {
+			t.Errorf("IsWorkday(%s) = %v, %d, %v; want %v, %d",
+				tt.date.Format("2006-01-02"), isWorkday, minutes, err, tt.wantWorkday, tt.wantMinutes)
+		}
+	}
+
+	// A year the file does not mention is left to the next provider
+	if _, err := fc.GetDayInfo(context.Background(), time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrDayNotCovered) {
+		t.Error("GetDayInfo() for an uncovered year: expected ErrDayNotCovered")
+	}
+	if _, err := fc.GetMonthInfo(context.Background(), 2027, time.January); !errors.Is(err, ErrDayNotCovered) {
+		t.Error("GetMonthInfo() for an uncovered year: expected ErrDayNotCovered")
+	}
+}
@@ -0,0 +1,197 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/username/time-tracker-bot/pkg/ics"
+	"go.uber.org/zap"
+)
+
+// ICSWorkingTimeProperty sets the working time of the days an all-day event
+// covers, in the format of the file calendar ("7", "6.5", "420m"). 0 makes
+// them days off; a time shorter than the base day makes them shortened days.
+const ICSWorkingTimeProperty = "X-WORKING-TIME"
+
+// icsRecurrenceYears is how many years after the current one recurring events are expanded
+const icsRecurrenceYears = 3
+
+// icsDayOffCategories mark all-day events as days off (compared in lower case)
+var icsDayOffCategories = map[string]bool{
+	"holiday":  true,
+	"day off":  true,
+	"dayoff":   true,
+	"vacation": true,
+	"выходной": true,
+	"праздник": true,
+	"отпуск":   true,
+}
+
+// icsDay is what an .ics file says about a date
+type icsDay struct {
+	dayOff  bool
+	minutes int
+	note    string
+}
+
+// ICSCalendar implements Calendar from an .ics file on top of a base calendar.
+// All-day events marked as days off (category Holiday/Vacation/Day off, Outlook
+// out-of-office, or every all-day event with allDayOff) become holidays, and
+// events with X-WORKING-TIME change the day's working time; other days come
+// from base. Over the production calendar it is an overlay; over a
+// StaticCalendar it is a standalone provider.
+type ICSCalendar struct {
+	path      string
+	base      Calendar
+	allDayOff bool
+	logger    *zap.Logger
+	days      map[string]icsDay // key: "YYYY-MM-DD"
+}
+
+// NewICSCalendar creates a calendar reading path over base; call Load before use
+func NewICSCalendar(path string, base Calendar, allDayOff bool, logger *zap.Logger) *ICSCalendar {
+	return &ICSCalendar{
+		path:      path,
+		base:      base,
+		allDayOff: allDayOff,
+		logger:    logger,
+		days:      make(map[string]icsDay),
+	}
+}
+
+// Load reads the .ics file. Timed events are ignored.
+func (ic *ICSCalendar) Load() error {
+	events, err := ics.ParseFile(ic.path)
+	if err != nil {
+		return err
+	}
+
+	// Recurring events (yearly holidays) are expanded a few years ahead
+	horizon := time.Date(time.Now().Year()+icsRecurrenceYears, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, event := range events {
+		if event.End.After(horizon) {
+			horizon = event.End
+		}
+	}
+	events, err = ics.Expand(events, time.Time{}, horizon)
+	if err != nil {
+		return err
+	}
+
+	days := make(map[string]icsDay)
+	for i := range events {
+		event := &events[i]
+		if !event.AllDay {
+			continue
+		}
+
+		day, ok, err := ic.classify(event)
+		if err != nil {
+			return fmt.Errorf("event %q: %w", event.Summary, err)
+		}
+		if !ok {
+			continue
+		}
+		for _, date := range event.Days() {
+			days[date.Format("2006-01-02")] = day
+		}
+	}
+	ic.days = days
+
+	ic.logger.Info("ICS calendar loaded",
+		zap.String("file", ic.path),
+		zap.Int("events", len(events)),
+		zap.Int("days", len(days)))
+
+	return nil
+}
+
+// classify turns an all-day event into a day override; false for events that
+// do not affect working time
+func (ic *ICSCalendar) classify(event *ics.Event) (icsDay, bool, error) {
+	if value := event.Get(ICSWorkingTimeProperty); value != "" {
+		minutes, err := ParseWorkingTime(value)
+		if err != nil {
+			return icsDay{}, false, err
+		}
+		return icsDay{dayOff: minutes == 0, minutes: minutes, note: event.Summary}, true, nil
+	}
+
+	dayOff := ic.allDayOff || strings.EqualFold(event.Get("X-MICROSOFT-CDO-BUSYSTATUS"), "OOF")
+	for _, category := range event.Categories {
+		if icsDayOffCategories[strings.ToLower(category)] {
+			dayOff = true
+		}
+	}
+	return icsDay{dayOff: true, note: event.Summary}, dayOff, nil
+}
+
+// apply overrides a base day
+func (d icsDay) apply(day DayInfo) DayInfo {
+	switch {
+	case d.dayOff:
+		day.Type = DayTypeHoliday
+		day.IsWorkday = false
+		day.WorkingMinutes = 0
+	case day.IsWorkday && d.minutes < day.WorkingMinutes:
+		day.Type = DayTypeShortened
+		day.WorkingMinutes = d.minutes
+	default:
+		day.Type = DayTypeWorkday
+		day.IsWorkday = true
+		day.WorkingMinutes = d.minutes
+	}
+	if d.note != "" {
+		day.Note = d.note
+	}
+	return day
+}
+
+// IsWorkday checks if the given date is a working day
+func (ic *ICSCalendar) IsWorkday(ctx context.Context, date time.Time) (bool, int, error) {
+	dayInfo, err := ic.GetDayInfo(ctx, date)
+	if err != nil {
+		return false, 0, err
+	}
+	return dayInfo.IsWorkday, dayInfo.WorkingMinutes, nil
+}
+
+// GetMonthInfo returns calendar info for the entire month
+func (ic *ICSCalendar) GetMonthInfo(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
+	info, err := ic.base.GetMonthInfo(ctx, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *info
+	result.Days = make([]DayInfo, len(info.Days))
+	changed := false
+	for i, day := range info.Days {
+		if override, ok := ic.days[day.Date.Format("2006-01-02")]; ok {
+			day = override.apply(day)
+			changed = true
+		}
+		result.Days[i] = day
+	}
+	if changed {
+		result.recount()
+	}
+
+	return &result, nil
+}
+
+// GetDayInfo returns detailed info for a specific day
+func (ic *ICSCalendar) GetDayInfo(ctx context.Context, date time.Time) (*DayInfo, error) {
+	info, err := ic.base.GetDayInfo(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	if override, ok := ic.days[date.Format("2006-01-02")]; ok {
+		day := override.apply(*info)
+		return &day, nil
+	}
+	return info, nil
+}
@@ -0,0 +1,107 @@
+package calendar
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const testICS = `BEGIN:VCALENDAR
+BEGIN:VEVENT
+DTSTART;VALUE=DATE:20251103
+DTEND;VALUE=DATE:20251105
+SUMMARY:Company holiday
+CATEGORIES:Holiday
+END:VEVENT
+BEGIN:VEVENT
+DTSTART;VALUE=DATE:20251107
+SUMMARY:Short Friday
+X-WORKING-TIME:6
+END:VEVENT
+BEGIN:VEVENT
+DTSTART;VALUE=DATE:20251108
+SUMMARY:Working Saturday
+X-WORKING-TIME:8
+END:VEVENT
+BEGIN:VEVENT
+DTSTART;VALUE=DATE:20251110
+SUMMARY:Birthday
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:20251111T100000Z
+DTEND:20251111T110000Z
+SUMMARY:Meeting
+CATEGORIES:Holiday
+END:VEVENT
+END:VCALENDAR
+`
+
+func newTestICSCalendar(t *testing.T, allDayOff bool) *ICSCalendar {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "holidays.ics")
+	if err := os.WriteFile(path, []byte(testICS), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cal := NewICSCalendar(path, NewStaticCalendar(0, nil), allDayOff, zap.NewNop())
+	if err := cal.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return cal
+}
+
+func TestICSCalendar_Days(t *testing.T) {
+	cal := newTestICSCalendar(t, false)
+
+	tests := []struct {
+		day       int
+		dayType   DayType
+		isWorkday bool
+		minutes   int
+	}{
+		{3, DayTypeHoliday, false, 0},
+		{4, DayTypeHoliday, false, 0},
+		{5, DayTypeWorkday, true, 480},
+		{7, DayTypeShortened, true, 360},
+		{8, DayTypeWorkday, true, 480},
+		{10, DayTypeWorkday, true, 480}, // unmarked all-day event
+		{11, DayTypeWorkday, true, 480}, // timed events are ignored
+	}
+	for _, tt := range tests {
+		day, err := cal.GetDayInfo(context.Background(), time.Date(2025, 11, tt.day, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("GetDayInfo(Nov %d) error = %v", tt.day, err)
+		}
+		if day.Type != tt.dayType || day.IsWorkday != tt.isWorkday || day.WorkingMinutes != tt.minutes {
+			t.Errorf("Nov %d = %v/%v/%d, want %v/%v/%d", tt.day, day.Type, day.IsWorkday, day.WorkingMinutes, tt.dayType, tt.isWorkday, tt.minutes)
+		}
+	}
+}
+
+func TestICSCalendar_MonthInfo(t *testing.T) {
+	cal := newTestICSCalendar(t, false)
+
+	info, err := cal.GetMonthInfo(context.Background(), 2025, time.November)
+	if err != nil {
+		t.Fatalf("GetMonthInfo() error = %v", err)
+	}
+	// Mon–Fri November has 20 workdays: 2 holidays, +1 working Saturday
+	if info.WorkDays != 19 || info.Holidays != 2 || info.Weekends != 9 {
+		t.Errorf("month = %d workdays / %d holidays / %d weekends, want 19 / 2 / 9", info.WorkDays, info.Holidays, info.Weekends)
+	}
+	if info.WorkingMinutes != 18*480+360 {
+		t.Errorf("WorkingMinutes = %d, want %d", info.WorkingMinutes, 18*480+360)
+	}
+}
+
+func TestICSCalendar_AllDayOff(t *testing.T) {
+	cal := newTestICSCalendar(t, true)
+
+	isWorkday, _, err := cal.IsWorkday(context.Background(), time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC))
+	if err != nil || isWorkday {
+		t.Errorf("IsWorkday(birthday) = %v, %v; want day off", isWorkday, err)
+	}
+}
@@ -1,7 +1,9 @@
 package calendar
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,20 +16,26 @@ import (
 )
 
 const (
-	isdayoffBaseURL     = "https://isdayoff.ru"
-	defaultHTTPTimeout  = 10 * time.Second
-	defaultCacheTTL     = 24 * time.Hour
+	isdayoffBaseURL    = "https://isdayoff.ru"
+	defaultHTTPTimeout = 10 * time.Second
+	defaultCacheTTL    = 24 * time.Hour
+	defaultCountry     = "ru"
 )
 
 // IsDayOffCalendar implements Calendar interface using isdayoff.ru API
 type IsDayOffCalendar struct {
-	httpClient  *http.Client
-	logger      *zap.Logger
-	cache       map[string]*cachedDayInfo
-	cacheMu     sync.RWMutex
-	cacheTTL    time.Duration
-	fallbackURL string
+	httpClient   *http.Client
+	logger       *zap.Logger
+	cache        map[string]*cachedDayInfo
+	months       map[string]*cachedMonth // "YYYY-MM" → whole month
+	cacheMu      sync.RWMutex
+	cacheTTL     time.Duration
+	fallbackURL  string
+	country      string                   // ISO 3166 code: ru, by, kz, uz, ...
 	fallbackData map[int]*xmlCalendarYear // year → calendar data
+	dayMinutes   int                      // full workday; shortened days are an hour less
+	fallbackOnly bool                     // xmlcalendar.ru provider: never call isdayoff.ru
+	disk         *diskCache               // nil = memory cache only
 }
 
 type cachedDayInfo struct {
@@ -37,8 +45,8 @@ type cachedDayInfo struct {
 
 // xmlCalendarYear represents xmlcalendar.ru JSON structure
 type xmlCalendarYear struct {
-	Year    int               `json:"year"`
-	Months  []xmlCalendarMonth `json:"months"`
+	Year      int                `json:"year"`
+	Months    []xmlCalendarMonth `json:"months"`
 	Statistic struct {
 		Workdays int     `json:"workdays"`
 		Holidays int     `json:"holidays"`
@@ -47,6 +55,16 @@ type xmlCalendarYear struct {
 	Transitions []xmlTransition `json:"transitions"`
 }
 
+// month returns the month entry, or nil when the year does not list it
+func (y *xmlCalendarYear) month(month time.Month) *xmlCalendarMonth {
+	for i := range y.Months {
+		if y.Months[i].Month == int(month) {
+			return &y.Months[i]
+		}
+	}
+	return nil
+}
+
 type xmlCalendarMonth struct {
 	Month int    `json:"month"`
 	Days  string `json:"days"` // "1*,2,3+,4,8,9,..." where * = shortened, + = transferred
@@ -57,11 +75,19 @@ type xmlTransition struct {
 	To   string `json:"to"`   // "MM.DD"
 }
 
-// NewIsDayOffCalendar creates a new IsDayOffCalendar instance
-func NewIsDayOffCalendar(fallbackURL string, cacheTTL time.Duration, logger *zap.Logger) *IsDayOffCalendar {
+// NewIsDayOffCalendar creates a new IsDayOffCalendar instance.
+// An empty fallbackURL disables the xmlcalendar.ru fallback. country selects the isdayoff.ru calendar (cc=) and fills {country} in fallbackURL (empty = ru).
+// dayMinutes is the length of a regular workday (0 = DefaultDayMinutes), e.g. 432 for a 36-hour week.
+func NewIsDayOffCalendar(fallbackURL, country string, cacheTTL time.Duration, dayMinutes int, logger *zap.Logger) *IsDayOffCalendar {
 	if cacheTTL == 0 {
 		cacheTTL = defaultCacheTTL
 	}
+	if country == "" {
+		country = defaultCountry
+	}
+	if dayMinutes <= 0 {
+		dayMinutes = DefaultDayMinutes
+	}
 
 	return &IsDayOffCalendar{
 		httpClient: &http.Client{
@@ -69,24 +95,51 @@ func NewIsDayOffCalendar(fallbackURL string, cacheTTL time.Duration, logger *zap
 		},
 		logger:       logger,
 		cache:        make(map[string]*cachedDayInfo),
+		months:       make(map[string]*cachedMonth),
 		cacheTTL:     cacheTTL,
 		fallbackURL:  fallbackURL,
+		country:      strings.ToLower(country),
 		fallbackData: make(map[int]*xmlCalendarYear),
+		dayMinutes:   dayMinutes,
 	}
 }
 
+// NewXMLCalendar creates a calendar that reads xmlcalendar.ru years only,
+// for use as a separate provider in a chain
+func NewXMLCalendar(url, country string, cacheTTL time.Duration, dayMinutes int, logger *zap.Logger) *IsDayOffCalendar {
+	c := NewIsDayOffCalendar(url, country, cacheTTL, dayMinutes, logger)
+	c.fallbackOnly = true
+	return c
+}
+
+// Country returns the country code of the calendar
+func (c *IsDayOffCalendar) Country() string {
+	return c.country
+}
+
+// EnableDiskCache persists fetched months and fallback years to path, so later runs
+// reuse them within the cache TTL and fall back to expired data when offline
+func (c *IsDayOffCalendar) EnableDiskCache(path string) error {
+	cache, err := openDiskCache(path)
+	if err != nil {
+		return err
+	}
+	c.disk = cache
+	return nil
+}
+
 // IsWorkday checks if the given date is a working day
-func (c *IsDayOffCalendar) IsWorkday(date time.Time) (bool, int, error) {
-	dayInfo, err := c.GetDayInfo(date)
+func (c *IsDayOffCalendar) IsWorkday(ctx context.Context, date time.Time) (bool, int, error) {
+	dayInfo, err := c.GetDayInfo(ctx, date)
 	if err != nil {
 		return false, 0, err
 	}
 
-	return dayInfo.IsWorkday, dayInfo.WorkingHours, nil
+	return dayInfo.IsWorkday, dayInfo.WorkingMinutes, nil
 }
 
 // GetDayInfo returns detailed info for a specific day
-func (c *IsDayOffCalendar) GetDayInfo(date time.Time) (*DayInfo, error) {
+func (c *IsDayOffCalendar) GetDayInfo(ctx context.Context, date time.Time) (*DayInfo, error) {
 	// Check cache
 	cacheKey := date.Format("2006-01-02")
 
@@ -101,22 +154,17 @@ func (c *IsDayOffCalendar) GetDayInfo(date time.Time) (*DayInfo, error) {
 	}
 	c.cacheMu.RUnlock()
 
-	// Try fetching from API
-	dayInfo, err := c.fetchDayFromAPI(date)
+	// Days come from the whole month, so a range of days costs one request per month
+	monthInfo, err := c.GetMonthInfo(ctx, date.Year(), date.Month())
 	if err != nil {
-		c.logger.Warn("Failed to fetch from API, trying fallback",
-			zap.String("date", cacheKey),
-			zap.Error(err))
-
-		// Try fallback
-		var fallbackErr error
-		dayInfo, fallbackErr = c.fetchDayFromFallback(date)
-		if fallbackErr != nil {
-			return nil, fmt.Errorf("API and fallback both failed: API=%w, Fallback=%v", err, fallbackErr)
-		}
-
-		c.logger.Info("Using fallback data", zap.String("date", cacheKey))
-		err = nil // Clear error since fallback succeeded
+		return nil, err
+	}
+	dayInfo, ok := monthInfo.Day(date)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s not in month data", ErrDayNotCovered, cacheKey)
+	}
+	if dayInfo.Stale {
+		return dayInfo, nil
 	}
 
 	// Update cache
@@ -127,74 +175,116 @@ func (c *IsDayOffCalendar) GetDayInfo(date time.Time) (*DayInfo, error) {
 	}
 	c.cacheMu.Unlock()
 
-	return dayInfo, err
+	return dayInfo, nil
 }
 
 // GetMonthInfo returns calendar info for the entire month
-func (c *IsDayOffCalendar) GetMonthInfo(year int, month time.Month) (*MonthInfo, error) {
-	// Try API first
-	monthInfo, err := c.fetchMonthFromAPI(year, month)
+func (c *IsDayOffCalendar) GetMonthInfo(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
+	monthKey := fmt.Sprintf("%d-%02d", year, month)
+
+	c.cacheMu.RLock()
+	if cached, ok := c.months[monthKey]; ok && time.Since(cached.fetchedAt) < c.cacheTTL {
+		c.cacheMu.RUnlock()
+		return cached.data, nil
+	}
+	c.cacheMu.RUnlock()
+
+	monthInfo, err := c.loadMonth(ctx, year, month)
 	if err != nil {
+		return nil, err
+	}
+	if monthInfo.Stale {
+		// Sources are retried on the next lookup rather than after the cache TTL
+		return monthInfo, nil
+	}
+
+	c.cacheMu.Lock()
+	c.months[monthKey] = &cachedMonth{data: monthInfo, fetchedAt: time.Now()}
+	c.cacheMu.Unlock()
+
+	return monthInfo, nil
+}
+
+// loadMonth fetches the month from the API, then the fallback, then expired disk cache.
+// A month from expired cache is marked Stale.
+func (c *IsDayOffCalendar) loadMonth(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
+	var errs []error
+
+	if !c.fallbackOnly {
+		monthInfo, err := c.fetchMonthFromAPI(ctx, year, month)
+		if err == nil {
+			return monthInfo, nil
+		}
 		c.logger.Warn("Failed to fetch month from API, trying fallback",
 			zap.Int("year", year),
 			zap.Int("month", int(month)),
 			zap.Error(err))
+		errs = append(errs, fmt.Errorf("API: %w", err))
+	}
 
-		// Try fallback
-		var fallbackErr error
-		monthInfo, fallbackErr = c.fetchMonthFromFallback(year, month)
-		if fallbackErr != nil {
-			return nil, fmt.Errorf("API and fallback both failed: API=%w, Fallback=%v", err, fallbackErr)
-		}
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	return monthInfo, nil
-}
+	if c.fallbackURL != "" {
+		monthInfo, err := c.fetchMonthFromFallback(ctx, year, month)
+		if err == nil {
+			return monthInfo, nil
+		}
+		errs = append(errs, fmt.Errorf("fallback: %w", err))
+	}
 
-// fetchDayFromAPI fetches single day from isdayoff.ru API
-func (c *IsDayOffCalendar) fetchDayFromAPI(date time.Time) (*DayInfo, error) {
-	// Use bulk API for the month and extract the day
-	monthInfo, err := c.fetchMonthFromAPI(date.Year(), date.Month())
-	if err != nil {
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	// Find the specific day
-	for _, day := range monthInfo.Days {
-		if day.Date.Year() == date.Year() &&
-			day.Date.Month() == date.Month() &&
-			day.Date.Day() == date.Day() {
-			return &day, nil
-		}
+	stale, ok := c.staleMonth(year, month)
+	if !ok {
+		return nil, fmt.Errorf("all calendar sources failed: %w", errors.Join(errs...))
+	}
+	c.logger.Warn("Calendar sources unavailable, using expired cached data",
+		zap.Int("year", year),
+		zap.Int("month", int(month)))
+	stale.markStale()
+	return stale, nil
+}
+
+// fetchMonthFromAPI returns the month from the disk cache while it is fresh,
+// otherwise from isdayoff.ru bulk API
+func (c *IsDayOffCalendar) fetchMonthFromAPI(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
+	if data, stale, ok := c.disk.month(c.monthCacheKey(year, month), c.cacheTTL); ok && !stale {
+		c.logger.Debug("Using disk-cached month",
+			zap.Int("year", year),
+			zap.Int("month", int(month)))
+		return c.parseBulkResponse(year, month, data)
 	}
 
-	return nil, fmt.Errorf("day not found in month data: %s", date.Format("2006-01-02"))
+	return c.downloadMonth(ctx, year, month)
 }
 
-// fetchMonthFromAPI fetches entire month from isdayoff.ru bulk API
-func (c *IsDayOffCalendar) fetchMonthFromAPI(year int, month time.Month) (*MonthInfo, error) {
-	// Build URL: https://isdayoff.ru/api/getdata?year=2025&month=11&pre=1
-	url := fmt.Sprintf("%s/api/getdata?year=%d&month=%d&pre=1",
-		isdayoffBaseURL, year, int(month))
+// downloadMonth fetches entire month from isdayoff.ru bulk API and stores it in the disk cache
+func (c *IsDayOffCalendar) downloadMonth(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
+	url := c.monthURL(year, month)
 
 	c.logger.Debug("Fetching month from isdayoff.ru",
 		zap.String("url", url),
+		zap.String("country", c.country),
 		zap.Int("year", year),
 		zap.Int("month", int(month)))
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch calendar data: %w", err)
+		return nil, fmt.Errorf("%w: failed to fetch calendar data: %w", ErrProviderUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("%w: API returned status %d", ErrProviderUnavailable, resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("%w: failed to read response: %w", ErrProviderUnavailable, err)
 	}
 
 	bulkData := string(body)
@@ -205,22 +295,125 @@ func (c *IsDayOffCalendar) fetchMonthFromAPI(year int, month time.Month) (*Month
 	// Parse bulk response
 	monthInfo, err := c.parseBulkResponse(year, month, bulkData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse bulk response: %w", err)
+		return nil, fmt.Errorf("%w: failed to parse bulk response: %w", ErrProviderUnavailable, err)
 	}
 
 	c.logger.Info("Month info fetched from API",
 		zap.Int("year", year),
 		zap.Int("month", int(month)),
-		zap.Int("working_hours", monthInfo.WorkingHours))
+		zap.Float64("working_hours", monthInfo.WorkingHours()))
+
+	if err := c.disk.putMonth(c.monthCacheKey(year, month), bulkData); err != nil {
+		c.logger.Warn("Failed to persist calendar cache", zap.Error(err))
+	}
 
 	return monthInfo, nil
 }
 
+// get sends a GET request bound to ctx
+func (c *IsDayOffCalendar) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.httpClient.Do(req)
+}
+
+// monthURL builds the bulk API URL: https://isdayoff.ru/api/getdata?year=2025&month=11&pre=1&cc=ru
+func (c *IsDayOffCalendar) monthURL(year int, month time.Month) string {
+	return fmt.Sprintf("%s/api/getdata?year=%d&month=%d&pre=1&cc=%s",
+		isdayoffBaseURL, year, int(month), c.country)
+}
+
+// fallbackYearURL fills {year} and {country} in the fallback URL template
+func (c *IsDayOffCalendar) fallbackYearURL(year int) string {
+	url := strings.ReplaceAll(c.fallbackURL, "{year}", strconv.Itoa(year))
+	return strings.ReplaceAll(url, "{country}", c.country)
+}
+
+// monthCacheKey and yearCacheKey identify disk cache entries per country
+func (c *IsDayOffCalendar) monthCacheKey(year int, month time.Month) string {
+	return fmt.Sprintf("%s/%d-%02d", c.country, year, month)
+}
+
+func (c *IsDayOffCalendar) yearCacheKey(year int) string {
+	return fmt.Sprintf("%s/%d", c.country, year)
+}
+
+// staleMonth returns disk-cached data for the month regardless of age.
+// It is the last resort when neither isdayoff.ru nor xmlcalendar.ru is reachable.
+func (c *IsDayOffCalendar) staleMonth(year int, month time.Month) (*MonthInfo, bool) {
+	if data, _, ok := c.disk.month(c.monthCacheKey(year, month), c.cacheTTL); ok {
+		if monthInfo, err := c.parseBulkResponse(year, month, data); err == nil {
+			return monthInfo, true
+		}
+	}
+
+	if yearData, _, ok := c.disk.year(c.yearCacheKey(year), c.cacheTTL); ok {
+		if xmlMonth := yearData.month(month); xmlMonth != nil {
+			if monthInfo, err := c.parseXMLCalendarMonth(year, month, xmlMonth); err == nil {
+				return monthInfo, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// PrefetchSummary reports what Prefetch stored in the disk cache
+type PrefetchSummary struct {
+	Year          int
+	APIMonths     int          // months fetched from isdayoff.ru
+	FallbackYear  bool         // xmlcalendar.ru year downloaded
+	MissingMonths []time.Month // months available from neither source
+}
+
+// Prefetch refreshes the disk cache with every month of the year from isdayoff.ru and
+// the xmlcalendar.ru year, so the bot can run offline for that year
+func (c *IsDayOffCalendar) Prefetch(ctx context.Context, year int) (*PrefetchSummary, error) {
+	if c.disk == nil {
+		return nil, fmt.Errorf("disk cache is not enabled")
+	}
+
+	summary := &PrefetchSummary{Year: year}
+
+	var yearData *xmlCalendarYear
+	if c.fallbackURL != "" {
+		var err error
+		yearData, err = c.downloadFallbackYear(ctx, year)
+		if err != nil {
+			c.logger.Warn("Failed to prefetch fallback year", zap.Int("year", year), zap.Error(err))
+		} else {
+			summary.FallbackYear = true
+			c.cacheMu.Lock()
+			c.fallbackData[year] = yearData
+			c.cacheMu.Unlock()
+		}
+	}
+
+	for month := time.January; month <= time.December; month++ {
+		if !c.fallbackOnly {
+			if _, err := c.downloadMonth(ctx, year, month); err == nil {
+				summary.APIMonths++
+				continue
+			}
+		}
+		if yearData == nil || yearData.month(month) == nil {
+			summary.MissingMonths = append(summary.MissingMonths, month)
+		}
+	}
+
+	if len(summary.MissingMonths) > 0 {
+		return summary, fmt.Errorf("no calendar data for %d month(s) of %d", len(summary.MissingMonths), year)
+	}
+	return summary, nil
+}
+
 // parseBulkResponse parses isdayoff.ru bulk response string
 // Format: "211100011000001100000110000011" where:
-// 0 = working day (8 hours)
+// 0 = working day (dayMinutes, 8 hours by default)
 // 1 = non-working day (holiday/weekend)
-// 2 = shortened day (7 hours)
+// 2 = shortened day (an hour less)
 func (c *IsDayOffCalendar) parseBulkResponse(year int, month time.Month, data string) (*MonthInfo, error) {
 	daysInMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
 
@@ -239,13 +432,13 @@ func (c *IsDayOffCalendar) parseBulkResponse(year int, month time.Month, data st
 		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
 
 		var dayType DayType
-		var workingHours int
+		var workingMinutes int
 		var isWorkday bool
 
 		switch code {
 		case '0': // Working day
 			dayType = DayTypeWorkday
-			workingHours = 8
+			workingMinutes = c.dayMinutes
 			isWorkday = true
 			monthInfo.WorkDays++
 		case '1': // Non-working (holiday or weekend)
@@ -256,62 +449,48 @@ func (c *IsDayOffCalendar) parseBulkResponse(year int, month time.Month, data st
 				dayType = DayTypeHoliday
 				monthInfo.Holidays++
 			}
-			workingHours = 0
+			workingMinutes = 0
 			isWorkday = false
 		case '2': // Shortened day
 			dayType = DayTypeShortened
-			workingHours = 7
+			workingMinutes = c.dayMinutes - shortenedDayCutMinutes
 			isWorkday = true
 			monthInfo.WorkDays++
 		default:
 			return nil, fmt.Errorf("unknown code '%c' at position %d", code, i)
 		}
 
-		monthInfo.WorkingHours += workingHours
+		monthInfo.WorkingMinutes += workingMinutes
 
 		monthInfo.Days = append(monthInfo.Days, DayInfo{
-			Date:         date,
-			Type:         dayType,
-			WorkingHours: workingHours,
-			IsWorkday:    isWorkday,
+			Date:           date,
+			Type:           dayType,
+			WorkingMinutes: workingMinutes,
+			IsWorkday:      isWorkday,
 		})
 	}
 
 	return monthInfo, nil
 }
 
-// fetchDayFromFallback fetches single day from xmlcalendar.ru fallback
-func (c *IsDayOffCalendar) fetchDayFromFallback(date time.Time) (*DayInfo, error) {
-	monthInfo, err := c.fetchMonthFromFallback(date.Year(), date.Month())
-	if err != nil {
-		return nil, err
-	}
-
-	// Find the specific day
-	for _, day := range monthInfo.Days {
-		if day.Date.Year() == date.Year() &&
-			day.Date.Month() == date.Month() &&
-			day.Date.Day() == date.Day() {
-			return &day, nil
-		}
-	}
-
-	return nil, fmt.Errorf("day not found in fallback data: %s", date.Format("2006-01-02"))
-}
-
 // fetchMonthFromFallback fetches month from xmlcalendar.ru
-func (c *IsDayOffCalendar) fetchMonthFromFallback(year int, month time.Month) (*MonthInfo, error) {
+func (c *IsDayOffCalendar) fetchMonthFromFallback(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
 	// Check if year data already loaded
 	c.cacheMu.RLock()
 	yearData, exists := c.fallbackData[year]
 	c.cacheMu.RUnlock()
 
 	if !exists {
-		// Download year data
-		var err error
-		yearData, err = c.downloadFallbackYear(year)
-		if err != nil {
-			return nil, fmt.Errorf("failed to download fallback data: %w", err)
+		cached, stale, ok := c.disk.year(c.yearCacheKey(year), c.cacheTTL)
+		if ok && !stale {
+			yearData = cached
+		} else {
+			// Download year data
+			var err error
+			yearData, err = c.downloadFallbackYear(ctx, year)
+			if err != nil {
+				return nil, fmt.Errorf("failed to download fallback data: %w", err)
+			}
 		}
 
 		// Cache year data
@@ -321,16 +500,9 @@ func (c *IsDayOffCalendar) fetchMonthFromFallback(year int, month time.Month) (*
 	}
 
 	// Find month in year data
-	var xmlMonth *xmlCalendarMonth
-	for i := range yearData.Months {
-		if yearData.Months[i].Month == int(month) {
-			xmlMonth = &yearData.Months[i]
-			break
-		}
-	}
-
+	xmlMonth := yearData.month(month)
 	if xmlMonth == nil {
-		return nil, fmt.Errorf("month %d not found in fallback data for year %d", month, year)
+		return nil, fmt.Errorf("%w: month %d not found in fallback data for year %d", ErrDayNotCovered, month, year)
 	}
 
 	// Parse xmlcalendar month format
@@ -338,32 +510,41 @@ func (c *IsDayOffCalendar) fetchMonthFromFallback(year int, month time.Month) (*
 }
 
 // downloadFallbackYear downloads entire year from xmlcalendar.ru
-func (c *IsDayOffCalendar) downloadFallbackYear(year int) (*xmlCalendarYear, error) {
-	url := strings.ReplaceAll(c.fallbackURL, "{year}", strconv.Itoa(year))
+func (c *IsDayOffCalendar) downloadFallbackYear(ctx context.Context, year int) (*xmlCalendarYear, error) {
+	url := c.fallbackYearURL(year)
 
 	c.logger.Info("Downloading fallback calendar data",
 		zap.String("url", url),
+		zap.String("country", c.country),
 		zap.Int("year", year))
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch fallback data: %w", err)
+		return nil, fmt.Errorf("%w: failed to fetch fallback data: %w", ErrProviderUnavailable, err)
 	}
 	defer resp.Body.Close()
 
+	// A year that is not published yet
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: fallback has no data for %d", ErrDayNotCovered, year)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("fallback API returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("%w: fallback API returned status %d", ErrProviderUnavailable, resp.StatusCode)
 	}
 
 	var yearData xmlCalendarYear
 	if err := json.NewDecoder(resp.Body).Decode(&yearData); err != nil {
-		return nil, fmt.Errorf("failed to parse fallback JSON: %w", err)
+		return nil, fmt.Errorf("%w: failed to parse fallback JSON: %w", ErrProviderUnavailable, err)
 	}
 
 	c.logger.Info("Fallback data downloaded",
 		zap.Int("year", year),
 		zap.Int("months", len(yearData.Months)))
 
+	if err := c.disk.putYear(c.yearCacheKey(year), &yearData); err != nil {
+		c.logger.Warn("Failed to persist calendar cache", zap.Error(err))
+	}
+
 	return &yearData, nil
 }
 
@@ -419,13 +600,13 @@ func (c *IsDayOffCalendar) parseXMLCalendarMonth(year int, month time.Month, xml
 		marker, isNonWorking := nonWorkingMap[day]
 
 		var dayType DayType
-		var workingHours int
+		var workingMinutes int
 		var isWorkday bool
 
 		if marker == '*' {
-			// Shortened day (working but 7 hours)
+			// Shortened day (working but an hour less)
 			dayType = DayTypeShortened
-			workingHours = 7
+			workingMinutes = c.dayMinutes - shortenedDayCutMinutes
 			isWorkday = true
 			monthInfo.WorkDays++
 		} else if isNonWorking {
@@ -437,23 +618,23 @@ func (c *IsDayOffCalendar) parseXMLCalendarMonth(year int, month time.Month, xml
 				dayType = DayTypeHoliday
 				monthInfo.Holidays++
 			}
-			workingHours = 0
+			workingMinutes = 0
 			isWorkday = false
 		} else {
 			// Regular working day
 			dayType = DayTypeWorkday
-			workingHours = 8
+			workingMinutes = c.dayMinutes
 			isWorkday = true
 			monthInfo.WorkDays++
 		}
 
-		monthInfo.WorkingHours += workingHours
+		monthInfo.WorkingMinutes += workingMinutes
 
 		monthInfo.Days = append(monthInfo.Days, DayInfo{
-			Date:         date,
-			Type:         dayType,
-			WorkingHours: workingHours,
-			IsWorkday:    isWorkday,
+			Date:           date,
+			Type:           dayType,
+			WorkingMinutes: workingMinutes,
+			IsWorkday:      isWorkday,
 		})
 	}
 
@@ -466,6 +647,7 @@ func (c *IsDayOffCalendar) ClearCache() {
 	defer c.cacheMu.Unlock()
 
 	c.cache = make(map[string]*cachedDayInfo)
+	c.months = make(map[string]*cachedMonth)
 	c.fallbackData = make(map[int]*xmlCalendarYear)
 	c.logger.Info("Calendar cache cleared")
 }
@@ -1,6 +1,7 @@
 package calendar
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -9,15 +10,15 @@ import (
 
 func TestIsDayOffCalendar_ParseBulkResponse(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
-	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", 24*time.Hour, logger)
+	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", "ru", 24*time.Hour, DefaultDayMinutes, logger)
 
 	tests := []struct {
-		name     string
-		year     int
-		month    time.Month
-		data     string
-		wantDays int
-		wantWork int
+		name      string
+		year      int
+		month     time.Month
+		data      string
+		wantDays  int
+		wantWork  int
 		wantHours int
 	}{
 		{
@@ -26,7 +27,7 @@ func TestIsDayOffCalendar_ParseBulkResponse(t *testing.T) {
 			month:     time.November,
 			data:      "211100011000001100000110000011", // 30 days
 			wantDays:  30,
-			wantWork:  19, // 18 working + 1 shortened
+			wantWork:  19,  // 18 working + 1 shortened
 			wantHours: 151, // 18*8 + 1*7 = 144 + 7 = 151
 		},
 		{
@@ -55,8 +56,8 @@ func TestIsDayOffCalendar_ParseBulkResponse(t *testing.T) {
 				t.Errorf("WorkDays = %d, want %d", monthInfo.WorkDays, tt.wantWork)
 			}
 
-			if monthInfo.WorkingHours != tt.wantHours {
-				t.Errorf("WorkingHours = %d, want %d", monthInfo.WorkingHours, tt.wantHours)
+			if monthInfo.WorkingHours() != float64(tt.wantHours) {
+				t.Errorf("WorkingHours = %v, want %d", monthInfo.WorkingHours(), tt.wantHours)
 			}
 		})
 	}
@@ -64,7 +65,7 @@ func TestIsDayOffCalendar_ParseBulkResponse(t *testing.T) {
 
 func TestIsDayOffCalendar_ParseBulkResponse_ShortenedDay(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
-	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", 24*time.Hour, logger)
+	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", "ru", 24*time.Hour, DefaultDayMinutes, logger)
 
 	// November 2025: First day (Nov 1) is shortened (code '2')
 	data := "211100011000001100000110000011"
@@ -78,17 +79,52 @@ func TestIsDayOffCalendar_ParseBulkResponse_ShortenedDay(t *testing.T) {
 	if nov1.Type != DayTypeShortened {
 		t.Errorf("Nov 1 Type = %v, want DayTypeShortened", nov1.Type)
 	}
-	if nov1.WorkingHours != 7 {
-		t.Errorf("Nov 1 WorkingHours = %d, want 7", nov1.WorkingHours)
+	if nov1.WorkingMinutes != 420 {
+		t.Errorf("Nov 1 WorkingMinutes = %d, want 420", nov1.WorkingMinutes)
 	}
 	if !nov1.IsWorkday {
 		t.Errorf("Nov 1 IsWorkday = false, want true")
 	}
 }
 
+func TestIsDayOffCalendar_ParseBulkResponse_ReducedWeek(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", "ru", 24*time.Hour, 432, logger)
+
+	// 36-hour week: 7.2h days, 6.2h shortened days
+	monthInfo, err := cal.parseBulkResponse(2025, time.November, "211100011000001100000110000011")
+	if err != nil {
+		t.Fatalf("parseBulkResponse() error = %v", err)
+	}
+
+	if monthInfo.Days[0].WorkingMinutes != 372 {
+		t.Errorf("Nov 1 WorkingMinutes = %d, want 372", monthInfo.Days[0].WorkingMinutes)
+	}
+	if monthInfo.WorkingMinutes != 18*432+372 {
+		t.Errorf("WorkingMinutes = %d, want %d", monthInfo.WorkingMinutes, 18*432+372)
+	}
+}
+
+func TestIsDayOffCalendar_CountryURLs(t *testing.T) {
+	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/{country}/{year}/calendar.json", "BY", 24*time.Hour, DefaultDayMinutes, zap.NewNop())
+
+	if got, want := cal.monthURL(2025, time.November), "https://isdayoff.ru/api/getdata?year=2025&month=11&pre=1&cc=by"; got != want {
+		t.Errorf("monthURL() = %q, want %q", got, want)
+	}
+	if got, want := cal.fallbackYearURL(2026), "https://xmlcalendar.ru/data/by/2026/calendar.json"; got != want {
+		t.Errorf("fallbackYearURL() = %q, want %q", got, want)
+	}
+
+	// Without a country the Russian calendar is used
+	cal = NewIsDayOffCalendar("https://xmlcalendar.ru/data/{country}/{year}/calendar.json", "", 24*time.Hour, DefaultDayMinutes, zap.NewNop())
+	if got, want := cal.fallbackYearURL(2026), "https://xmlcalendar.ru/data/ru/2026/calendar.json"; got != want {
+		t.Errorf("fallbackYearURL() = %q, want %q", got, want)
+	}
+}
+
 func TestIsDayOffCalendar_ParseBulkResponse_InvalidLength(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
-	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", 24*time.Hour, logger)
+	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", "ru", 24*time.Hour, DefaultDayMinutes, logger)
 
 	// November has 30 days, but providing only 29
 	data := "21110001100000110000011000001"
@@ -100,7 +136,7 @@ func TestIsDayOffCalendar_ParseBulkResponse_InvalidLength(t *testing.T) {
 
 func TestIsDayOffCalendar_ParseXMLCalendarMonth(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
-	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", 24*time.Hour, logger)
+	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", "ru", 24*time.Hour, DefaultDayMinutes, logger)
 
 	tests := []struct {
 		name      string
@@ -115,15 +151,15 @@ func TestIsDayOffCalendar_ParseXMLCalendarMonth(t *testing.T) {
 			year:      2025,
 			month:     time.November,
 			daysStr:   "1*,2,3+,4,8,9,15,16,22,23,29,30", // 1*=shortened, rest=holidays/weekends
-			wantWork:  19, // 30 days - 11 non-working (excluding 1* which is working/shortened)
-			wantHours: 151, // 18*8 + 1*7
+			wantWork:  19,                                // 30 days - 11 non-working (excluding 1* which is working/shortened)
+			wantHours: 151,                               // 18*8 + 1*7
 		},
 		{
 			name:      "July 2025",
 			year:      2025,
 			month:     time.July,
 			daysStr:   "5,6,12,13,19,20,26,27", // 8 weekends
-			wantWork:  23, // 31 - 8
+			wantWork:  23,                      // 31 - 8
 			wantHours: 184,
 		},
 	}
@@ -144,8 +180,8 @@ func TestIsDayOffCalendar_ParseXMLCalendarMonth(t *testing.T) {
 				t.Errorf("WorkDays = %d, want %d", monthInfo.WorkDays, tt.wantWork)
 			}
 
-			if monthInfo.WorkingHours != tt.wantHours {
-				t.Errorf("WorkingHours = %d, want %d", monthInfo.WorkingHours, tt.wantHours)
+			if monthInfo.WorkingHours() != float64(tt.wantHours) {
+				t.Errorf("WorkingHours = %v, want %d", monthInfo.WorkingHours(), tt.wantHours)
 			}
 		})
 	}
@@ -153,7 +189,7 @@ func TestIsDayOffCalendar_ParseXMLCalendarMonth(t *testing.T) {
 
 func TestIsDayOffCalendar_ParseXMLCalendarMonth_ShortenedDay(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
-	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", 24*time.Hour, logger)
+	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", "ru", 24*time.Hour, DefaultDayMinutes, logger)
 
 	xmlMonth := &xmlCalendarMonth{
 		Month: 11,
@@ -170,8 +206,8 @@ func TestIsDayOffCalendar_ParseXMLCalendarMonth_ShortenedDay(t *testing.T) {
 	if nov1.Type != DayTypeShortened {
 		t.Errorf("Nov 1 Type = %v, want DayTypeShortened", nov1.Type)
 	}
-	if nov1.WorkingHours != 7 {
-		t.Errorf("Nov 1 WorkingHours = %d, want 7", nov1.WorkingHours)
+	if nov1.WorkingMinutes != 420 {
+		t.Errorf("Nov 1 WorkingMinutes = %d, want 420", nov1.WorkingMinutes)
 	}
 
 	// Check Nov 3 (transferred, index 2)
@@ -179,22 +215,22 @@ func TestIsDayOffCalendar_ParseXMLCalendarMonth_ShortenedDay(t *testing.T) {
 	if nov3.Type != DayTypeHoliday {
 		t.Errorf("Nov 3 Type = %v, want DayTypeHoliday (transferred)", nov3.Type)
 	}
-	if nov3.WorkingHours != 0 {
-		t.Errorf("Nov 3 WorkingHours = %d, want 0", nov3.WorkingHours)
+	if nov3.WorkingMinutes != 0 {
+		t.Errorf("Nov 3 WorkingMinutes = %d, want 0", nov3.WorkingMinutes)
 	}
 }
 
 func TestIsDayOffCalendar_Cache(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
-	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", 1*time.Second, logger)
+	cal := NewIsDayOffCalendar("https://xmlcalendar.ru/data/ru/{year}/calendar.json", "ru", 1*time.Second, DefaultDayMinutes, logger)
 
 	// Manually populate cache
 	date := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
 	dayInfo := &DayInfo{
-		Date:         date,
-		Type:         DayTypeShortened,
-		WorkingHours: 7,
-		IsWorkday:    true,
+		Date:           date,
+		Type:           DayTypeShortened,
+		WorkingMinutes: 420,
+		IsWorkday:      true,
 	}
 
 	cal.cacheMu.Lock()
@@ -205,13 +241,13 @@ func TestIsDayOffCalendar_Cache(t *testing.T) {
 	cal.cacheMu.Unlock()
 
 	// Should hit cache (no API call)
-	result, err := cal.GetDayInfo(date)
+	result, err := cal.GetDayInfo(context.Background(), date)
 	if err != nil {
 		t.Fatalf("GetDayInfo() error = %v", err)
 	}
 
-	if result.WorkingHours != 7 {
-		t.Errorf("Cached WorkingHours = %d, want 7", result.WorkingHours)
+	if result.WorkingMinutes != 420 {
+		t.Errorf("Cached WorkingMinutes = %d, want 420", result.WorkingMinutes)
 	}
 
 	// Wait for cache to expire
@@ -0,0 +1,191 @@
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/state"
+	"go.uber.org/zap"
+)
+
+// DayTypeTimeOff marks a workday taken off personally (vacation, sick leave, day off)
+const DayTypeTimeOff DayType = DayTypeShortened + 1
+
+func init() {
+	dayTypeNames[DayTypeTimeOff] = "timeoff"
+}
+
+// Time off kinds
+const (
+	TimeOffVacation = "vacation"
+	TimeOffSick     = "sick"
+	TimeOffDayOff   = "dayoff"
+)
+
+// TimeOff is a personal absence range, both ends inclusive
+type TimeOff struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	Kind string    `json:"kind"`
+	Note string    `json:"note,omitempty"`
+}
+
+// Contains reports whether the date falls into the range
+func (t TimeOff) Contains(date time.Time) bool {
+	day := date.Format("2006-01-02")
+	return day >= t.From.Format("2006-01-02") && day <= t.To.Format("2006-01-02")
+}
+
+// TimeOffLookup is implemented by calendars that know personal time off
+type TimeOffLookup interface {
+	// TimeOffOn returns the time off covering the date
+	TimeOffOn(date time.Time) (TimeOff, bool)
+}
+
+// OverlayCalendar applies personal time off on top of a base calendar.
+// Time off turns workdays into days off, except kinds for which keepWorkday
+// returns true: those stay workdays so the absence itself can be logged.
+type OverlayCalendar struct {
+	base        Calendar
+	ranges      []TimeOff
+	keepWorkday func(kind string) bool
+	logger      *zap.Logger
+}
+
+// NewOverlayCalendar creates a time off overlay over base. keepWorkday may be nil.
+func NewOverlayCalendar(base Calendar, ranges []TimeOff, keepWorkday func(kind string) bool, logger *zap.Logger) *OverlayCalendar {
+	if keepWorkday == nil {
+		keepWorkday = func(string) bool { return false }
+	}
+	return &OverlayCalendar{
+		base:        base,
+		ranges:      ranges,
+		keepWorkday: keepWorkday,
+		logger:      logger,
+	}
+}
+
+// TimeOffOn returns the time off covering the date
+func (oc *OverlayCalendar) TimeOffOn(date time.Time) (TimeOff, bool) {
+	for _, r := range oc.ranges {
+		if r.Contains(date) {
+			return r, true
+		}
+	}
+	return TimeOff{}, false
+}
+
+// dayOff reports whether time off removes the date from workdays
+func (oc *OverlayCalendar) dayOff(date time.Time) (TimeOff, bool) {
+	timeOff, ok := oc.TimeOffOn(date)
+	if !ok || oc.keepWorkday(timeOff.Kind) {
+		return TimeOff{}, false
+	}
+	return timeOff, true
+}
+
+// IsWorkday checks if the given date is a working day
+func (oc *OverlayCalendar) IsWorkday(ctx context.Context, date time.Time) (bool, int, error) {
+	isWorkday, minutes, err := oc.base.IsWorkday(ctx, date)
+	if err != nil || !isWorkday {
+		return isWorkday, minutes, err
+	}
+
+	if timeOff, ok := oc.dayOff(date); ok {
+		oc.logger.Debug("Personal time off",
+			zap.Time("date", date),
+			zap.String("kind", timeOff.Kind))
+		return false, 0, nil
+	}
+
+	return true, minutes, nil
+}
+
+// GetMonthInfo returns calendar info for the entire month
+func (oc *OverlayCalendar) GetMonthInfo(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
+	info, err := oc.base.GetMonthInfo(ctx, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *info
+	result.Days = make([]DayInfo, len(info.Days))
+	for i, day := range info.Days {
+		if day.IsWorkday {
+			if timeOff, ok := oc.dayOff(day.Date); ok {
+				result.WorkDays--
+				result.WorkingMinutes -= day.WorkingMinutes
+				day = applyTimeOff(day, timeOff)
+			}
+		}
+		result.Days[i] = day
+	}
+
+	return &result, nil
+}
+
+// GetDayInfo returns detailed info for a specific day
+func (oc *OverlayCalendar) GetDayInfo(ctx context.Context, date time.Time) (*DayInfo, error) {
+	info, err := oc.base.GetDayInfo(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsWorkday {
+		if timeOff, ok := oc.dayOff(date); ok {
+			day := applyTimeOff(*info, timeOff)
+			return &day, nil
+		}
+	}
+
+	return info, nil
+}
+
+func applyTimeOff(day DayInfo, timeOff TimeOff) DayInfo {
+	day.Type = DayTypeTimeOff
+	day.IsWorkday = false
+	day.WorkingMinutes = 0
+	day.Note = timeOff.Kind
+	if timeOff.Note != "" {
+		day.Note += ": " + timeOff.Note
+	}
+	return day
+}
+
+// LoadTimeOffFile reads time off ranges managed by the timeoff command.
+// A missing file means no time off.
+func LoadTimeOffFile(path string) ([]TimeOff, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read time off file: %w", err)
+	}
+
+	var ranges []TimeOff
+	if err := json.Unmarshal(data, &ranges); err != nil {
+		return nil, fmt.Errorf("failed to parse time off file: %w", err)
+	}
+	return ranges, nil
+}
+
+// SaveTimeOffFile atomically writes time off ranges sorted by start date
+func SaveTimeOffFile(path string, ranges []TimeOff) error {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].From.Before(ranges[j].From)
+	})
+
+	data, err := json.MarshalIndent(ranges, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal time off: %w", err)
+	}
+	if err := state.WriteFileAtomic(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write time off file: %w", err)
+	}
+	return nil
+}
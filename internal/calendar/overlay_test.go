@@ -0,0 +1,102 @@
+package calendar
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// weekdayCalendar is a base calendar where Mon–Fri are 8h workdays
+type weekdayCalendar struct{}
+
+func (weekdayCalendar) IsWorkday(ctx context.Context, date time.Time) (bool, int, error) {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false, 0, nil
+	}
+	return true, DefaultDayMinutes, nil
+}
+
+func (c weekdayCalendar) GetMonthInfo(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
+	info := &MonthInfo{Year: year, Month: month}
+	for d := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC); d.Month() == month; d = d.AddDate(0, 0, 1) {
+		day, _ := c.GetDayInfo(ctx, d)
+		if day.IsWorkday {
+			info.WorkDays++
+			info.WorkingMinutes += day.WorkingMinutes
+		}
+		info.Days = append(info.Days, *day)
+	}
+	return info, nil
+}
+
+func (c weekdayCalendar) GetDayInfo(ctx context.Context, date time.Time) (*DayInfo, error) {
+	isWorkday, minutes, _ := c.IsWorkday(ctx, date)
+	dayType := DayTypeWorkday
+	if !isWorkday {
+		dayType = DayTypeWeekend
+	}
+	return &DayInfo{Date: date, Type: dayType, WorkingMinutes: minutes, IsWorkday: isWorkday}, nil
+}
+
+func TestOverlayCalendar(t *testing.T) {
+	ranges := []TimeOff{
+		{From: time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 11, 14, 0, 0, 0, 0, time.UTC), Kind: TimeOffVacation},
+		{From: time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC), Kind: TimeOffSick},
+	}
+	// Sick leave is logged to an absence issue, so that day stays a workday
+	keep := func(kind string) bool { return kind == TimeOffSick }
+	oc := NewOverlayCalendar(weekdayCalendar{}, ranges, keep, zap.NewNop())
+
+	vacationDay := time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC)
+	if isWorkday, minutes, _ := oc.IsWorkday(context.Background(), vacationDay); isWorkday || minutes != 0 {
+		t.Errorf("vacation day: IsWorkday() = %v, %d; want false, 0", isWorkday, minutes)
+	}
+
+	sickDay := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	if isWorkday, minutes, _ := oc.IsWorkday(context.Background(), sickDay); !isWorkday || minutes != 480 {
+		t.Errorf("logged sick day: IsWorkday() = %v, %d; want true, 480", isWorkday, minutes)
+	}
+	if timeOff, ok := oc.TimeOffOn(sickDay); !ok || timeOff.Kind != TimeOffSick {
+		t.Errorf("TimeOffOn(sick day) = %+v, %v", timeOff, ok)
+	}
+
+	info, err := oc.GetMonthInfo(context.Background(), 2025, time.November)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// November 2025 has 20 weekdays, 5 of them on vacation
+	if info.WorkDays != 15 || info.WorkingHours() != 120 {
+		t.Errorf("GetMonthInfo() = %d days / %vh, want 15 / 120h", info.WorkDays, info.WorkingHours())
+	}
+	if day := info.Days[vacationDay.Day()-1]; day.Type != DayTypeTimeOff {
+		t.Errorf("vacation day type = %v, want DayTypeTimeOff", day.Type)
+	}
+}
+
+func TestTimeOffFile_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "time_off.json")
+
+	ranges, err := LoadTimeOffFile(path)
+	if err != nil || len(ranges) != 0 {
+		t.Fatalf("LoadTimeOffFile(missing) = %v, %v; want empty", ranges, err)
+	}
+
+	want := []TimeOff{
+		{From: time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), Kind: TimeOffDayOff},
+		{From: time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 1, 9, 0, 0, 0, 0, time.UTC), Kind: TimeOffVacation, Note: "Winter"},
+	}
+	if err := SaveTimeOffFile(path, want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadTimeOffFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Kind != TimeOffVacation || got[0].Note != "Winter" {
+		t.Errorf("LoadTimeOffFile() = %+v, want ranges sorted by start", got)
+	}
+}
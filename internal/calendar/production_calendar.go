@@ -1,8 +1,10 @@
 package calendar
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"sync"
 	"time"
@@ -16,14 +18,14 @@ const (
 
 // ProductionCalendar implements Calendar interface using production-calendar.ru API
 type ProductionCalendar struct {
-	apiURL      string
-	apiToken    string
-	country     string
-	cacheTTL    time.Duration
-	httpClient  *http.Client
-	logger      *zap.Logger
-	cache       map[string]*cachedMonth
-	cacheMu     sync.RWMutex
+	apiURL     string
+	apiToken   string
+	country    string
+	cacheTTL   time.Duration
+	httpClient *http.Client
+	logger     *zap.Logger
+	cache      map[string]*cachedMonth
+	cacheMu    sync.RWMutex
 }
 
 type cachedMonth struct {
@@ -38,25 +40,25 @@ type productionCalendarResponse struct {
 	DTStart     string `json:"dt_start"`
 	DTEnd       string `json:"dt_end"`
 	Statistic   struct {
-		CalendarDays              int `json:"calendar_days"`
-		CalendarDaysWithoutHolidays int `json:"calendar_days_without_holidays"`
-		WorkDays                  int `json:"work_days"`
-		Weekends                  int `json:"weekends"`
-		Holidays                  int `json:"holidays"`
-		ShortenedWorkingDays      int `json:"shortened_working_days"`
-		WorkingHours              int `json:"working_hours"`
+		CalendarDays                int     `json:"calendar_days"`
+		CalendarDaysWithoutHolidays int     `json:"calendar_days_without_holidays"`
+		WorkDays                    int     `json:"work_days"`
+		Weekends                    int     `json:"weekends"`
+		Holidays                    int     `json:"holidays"`
+		ShortenedWorkingDays        int     `json:"shortened_working_days"`
+		WorkingHours                float64 `json:"working_hours"`
 	} `json:"statistic"`
 	Days json.RawMessage `json:"days"` // Can be array OR error string (guest token limitation)
 }
 
 // calendarDay represents a single day in the calendar
 type calendarDay struct {
-	Date         string `json:"date"`
-	TypeID       int    `json:"type_id"`
-	TypeText     string `json:"type_text"`
-	Note         string `json:"note,omitempty"`
-	WeekDay      string `json:"week_day"`
-	WorkingHours int    `json:"working_hours"`
+	Date         string  `json:"date"`
+	TypeID       int     `json:"type_id"`
+	TypeText     string  `json:"type_text"`
+	Note         string  `json:"note,omitempty"`
+	WeekDay      string  `json:"week_day"`
+	WorkingHours float64 `json:"working_hours"` // Fractional for reduced weeks (e.g. 7.2)
 }
 
 // NewProductionCalendar creates a new ProductionCalendar instance
@@ -75,17 +77,17 @@ func NewProductionCalendar(apiURL, apiToken, country string, cacheTTL time.Durat
 }
 
 // IsWorkday checks if the given date is a working day
-func (pc *ProductionCalendar) IsWorkday(date time.Time) (bool, int, error) {
-	dayInfo, err := pc.GetDayInfo(date)
+func (pc *ProductionCalendar) IsWorkday(ctx context.Context, date time.Time) (bool, int, error) {
+	dayInfo, err := pc.GetDayInfo(ctx, date)
 	if err != nil {
 		return false, 0, err
 	}
 
-	return dayInfo.IsWorkday, dayInfo.WorkingHours, nil
+	return dayInfo.IsWorkday, dayInfo.WorkingMinutes, nil
 }
 
 // GetMonthInfo returns calendar info for the entire month
-func (pc *ProductionCalendar) GetMonthInfo(year int, month time.Month) (*MonthInfo, error) {
+func (pc *ProductionCalendar) GetMonthInfo(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
 	// Check cache
 	cacheKey := fmt.Sprintf("%d-%02d", year, month)
 
@@ -102,7 +104,7 @@ func (pc *ProductionCalendar) GetMonthInfo(year int, month time.Month) (*MonthIn
 	pc.cacheMu.RUnlock()
 
 	// Fetch from API
-	monthInfo, err := pc.fetchMonthInfo(year, month)
+	monthInfo, err := pc.fetchMonthInfo(ctx, year, month)
 	if err != nil {
 		return nil, err
 	}
@@ -118,14 +120,14 @@ func (pc *ProductionCalendar) GetMonthInfo(year int, month time.Month) (*MonthIn
 	pc.logger.Info("Month info fetched and cached",
 		zap.Int("year", year),
 		zap.Int("month", int(month)),
-		zap.Int("working_hours", monthInfo.WorkingHours))
+		zap.Float64("working_hours", monthInfo.WorkingHours()))
 
 	return monthInfo, nil
 }
 
 // GetDayInfo returns detailed info for a specific day
-func (pc *ProductionCalendar) GetDayInfo(date time.Time) (*DayInfo, error) {
-	monthInfo, err := pc.GetMonthInfo(date.Year(), date.Month())
+func (pc *ProductionCalendar) GetDayInfo(ctx context.Context, date time.Time) (*DayInfo, error) {
+	monthInfo, err := pc.GetMonthInfo(ctx, date.Year(), date.Month())
 	if err != nil {
 		return nil, err
 	}
@@ -139,11 +141,11 @@ func (pc *ProductionCalendar) GetDayInfo(date time.Time) (*DayInfo, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("day not found in calendar data: %s", date.Format("2006-01-02"))
+	return nil, fmt.Errorf("%w: %s not in calendar data", ErrDayNotCovered, date.Format("2006-01-02"))
 }
 
 // fetchMonthInfo fetches month info from API
-func (pc *ProductionCalendar) fetchMonthInfo(year int, month time.Month) (*MonthInfo, error) {
+func (pc *ProductionCalendar) fetchMonthInfo(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
 	// Build URL: https://production-calendar.ru/get-period/{token}/{country}/{MM.YYYY}/json
 	period := fmt.Sprintf("%02d.%d", month, year)
 	url := fmt.Sprintf("%s/get-period/%s/%s/%s/json",
@@ -154,23 +156,27 @@ func (pc *ProductionCalendar) fetchMonthInfo(year int, month time.Month) (*Month
 		zap.Int("year", year),
 		zap.Int("month", int(month)))
 
-	resp, err := pc.httpClient.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch calendar data: %w", err)
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	resp, err := pc.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch calendar data: %w", ErrProviderUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("%w: API returned status %d", ErrProviderUnavailable, resp.StatusCode)
 	}
 
 	var apiResp productionCalendarResponse
 	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse API response: %w", err)
+		return nil, fmt.Errorf("%w: failed to parse API response: %w", ErrProviderUnavailable, err)
 	}
 
 	if apiResp.Status != "ok" {
-		return nil, fmt.Errorf("API returned status: %s", apiResp.Status)
+		return nil, fmt.Errorf("%w: API returned status: %s", ErrProviderUnavailable, apiResp.Status)
 	}
 
 	// Try to parse Days as array
@@ -179,20 +185,20 @@ func (pc *ProductionCalendar) fetchMonthInfo(year int, month time.Month) (*Month
 		// If failed, Days might be an error message string (guest token limitation)
 		var errorMsg string
 		if err2 := json.Unmarshal(apiResp.Days, &errorMsg); err2 == nil {
-			return nil, fmt.Errorf("API error: %s", errorMsg)
+			return nil, fmt.Errorf("%w: API error: %s", ErrProviderUnavailable, errorMsg)
 		}
-		return nil, fmt.Errorf("failed to parse days: %w", err)
+		return nil, fmt.Errorf("%w: failed to parse days: %w", ErrProviderUnavailable, err)
 	}
 
 	// Convert to MonthInfo
 	monthInfo := &MonthInfo{
-		Year:         year,
-		Month:        month,
-		WorkingHours: apiResp.Statistic.WorkingHours,
-		WorkDays:     apiResp.Statistic.WorkDays,
-		Weekends:     apiResp.Statistic.Weekends,
-		Holidays:     apiResp.Statistic.Holidays,
-		Days:         make([]DayInfo, 0, len(days)),
+		Year:           year,
+		Month:          month,
+		WorkingMinutes: hoursToMinutes(apiResp.Statistic.WorkingHours),
+		WorkDays:       apiResp.Statistic.WorkDays,
+		Weekends:       apiResp.Statistic.Weekends,
+		Holidays:       apiResp.Statistic.Holidays,
+		Days:           make([]DayInfo, 0, len(days)),
 	}
 
 	for _, apiDay := range days {
@@ -209,17 +215,22 @@ func (pc *ProductionCalendar) fetchMonthInfo(year int, month time.Month) (*Month
 		isWorkday := apiDay.WorkingHours > 0
 
 		monthInfo.Days = append(monthInfo.Days, DayInfo{
-			Date:         date,
-			Type:         dayType,
-			WorkingHours: apiDay.WorkingHours,
-			IsWorkday:    isWorkday,
-			Note:         apiDay.Note,
+			Date:           date,
+			Type:           dayType,
+			WorkingMinutes: hoursToMinutes(apiDay.WorkingHours),
+			IsWorkday:      isWorkday,
+			Note:           apiDay.Note,
 		})
 	}
 
 	return monthInfo, nil
 }
 
+// hoursToMinutes converts fractional API hours (e.g. 7.2) to whole minutes
+func hoursToMinutes(hours float64) int {
+	return int(math.Round(hours * 60))
+}
+
 // ClearCache clears the cache
 func (pc *ProductionCalendar) ClearCache() {
 	pc.cacheMu.Lock()
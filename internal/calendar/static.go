@@ -0,0 +1,72 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StaticCalendar implements Calendar from weekday rules only: listed weekdays are
+// workdays of a fixed length, the rest are weekends. It never fails, so it is a
+// last-resort provider at the end of a chain.
+type StaticCalendar struct {
+	dayMinutes int
+	workdays   map[time.Weekday]bool
+}
+
+// NewStaticCalendar creates a calendar where workdays last dayMinutes
+// (0 = DefaultDayMinutes); no workdays means Monday to Friday
+func NewStaticCalendar(dayMinutes int, workdays []time.Weekday) *StaticCalendar {
+	if dayMinutes <= 0 {
+		dayMinutes = DefaultDayMinutes
+	}
+	if len(workdays) == 0 {
+		workdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	}
+
+	set := make(map[time.Weekday]bool, len(workdays))
+	for _, day := range workdays {
+		set[day] = true
+	}
+	return &StaticCalendar{dayMinutes: dayMinutes, workdays: set}
+}
+
+// IsWorkday checks if the given date is a working day
+func (sc *StaticCalendar) IsWorkday(ctx context.Context, date time.Time) (bool, int, error) {
+	day := sc.day(date)
+	return day.IsWorkday, day.WorkingMinutes, nil
+}
+
+// GetMonthInfo returns calendar info for the entire month
+func (sc *StaticCalendar) GetMonthInfo(ctx context.Context, year int, month time.Month) (*MonthInfo, error) {
+	if month < time.January || month > time.December {
+		return nil, fmt.Errorf("invalid month: %d", month)
+	}
+
+	monthInfo := &MonthInfo{Year: year, Month: month}
+	for d := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC); d.Month() == month; d = d.AddDate(0, 0, 1) {
+		day := sc.day(d)
+		if day.IsWorkday {
+			monthInfo.WorkDays++
+			monthInfo.WorkingMinutes += day.WorkingMinutes
+		} else {
+			monthInfo.Weekends++
+		}
+		monthInfo.Days = append(monthInfo.Days, day)
+	}
+	return monthInfo, nil
+}
+
+// GetDayInfo returns detailed info for a specific day
+func (sc *StaticCalendar) GetDayInfo(ctx context.Context, date time.Time) (*DayInfo, error) {
+	day := sc.day(date)
+	return &day, nil
+}
+
+func (sc *StaticCalendar) day(date time.Time) DayInfo {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	if sc.workdays[date.Weekday()] {
+		return DayInfo{Date: date, Type: DayTypeWorkday, WorkingMinutes: sc.dayMinutes, IsWorkday: true}
+	}
+	return DayInfo{Date: date, Type: DayTypeWeekend}
+}
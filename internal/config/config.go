@@ -2,10 +2,15 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
+	"github.com/username/time-tracker-bot/pkg/dateutil"
 )
 
 // Config represents application configuration
@@ -16,43 +21,194 @@ type Config struct {
 	Daemon    DaemonConfig    `mapstructure:"daemon"`
 	IAM       IAMConfig       `mapstructure:"iam"`
 	State     StateConfig     `mapstructure:"state"`
+	TimeOff   TimeOffConfig   `mapstructure:"time_off"`
+	Meetings  MeetingsConfig  `mapstructure:"meetings"`
+	Activity  ActivityConfig  `mapstructure:"activity"`
 }
 
 // TrackerConfig represents Yandex Tracker configuration
 type TrackerConfig struct {
 	OrgID       string `mapstructure:"org_id"`
 	APIEndpoint string `mapstructure:"api_endpoint"`
-	BoardID     int    `mapstructure:"board_id"`
+	BoardID     int    `mapstructure:"board_id"` // Legacy single board; used when sources is empty
 	IssuesQuery string `mapstructure:"issues_query"`
+
+	// Boards and queues whose issues are candidates for time
+	Sources []TrackerSourceConfig `mapstructure:"sources"`
+}
+
+// TrackerSourceConfig describes one board or queue issues are taken from
+type TrackerSourceConfig struct {
+	Name   string  `mapstructure:"name"`   // Shown in the per-day breakdown (default: board:<id> or queue:<key>)
+	Board  int     `mapstructure:"board"`  // Board ID
+	Queue  string  `mapstructure:"queue"`  // Queue key, instead of board
+	Weight float64 `mapstructure:"weight"` // Multiplies weights of the source's issues (default 1)
+	Share  float64 `mapstructure:"share"`  // Fixed fraction of distributed time (0..1), instead of weight
+}
+
+// GetName returns the source name used in logs and reports
+func (s *TrackerSourceConfig) GetName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	if s.Queue != "" {
+		return "queue:" + s.Queue
+	}
+	return fmt.Sprintf("board:%d", s.Board)
+}
+
+// GetWeight returns the weight of the source's issues (default 1)
+func (s *TrackerSourceConfig) GetWeight() float64 {
+	if s.Weight <= 0 {
+		return 1
+	}
+	return s.Weight
 }
 
 // CalendarConfig represents calendar configuration
 type CalendarConfig struct {
 	Type        string `mapstructure:"type"`         // "isdayoff" or "production-calendar"
-	FallbackURL string `mapstructure:"fallback_url"` // For isdayoff type (xmlcalendar.ru)
+	FallbackURL string `mapstructure:"fallback_url"` // For isdayoff type (xmlcalendar.ru), may use {year} and {country}
 	CacheTTL    string `mapstructure:"cache_ttl"`
+	Country     string `mapstructure:"country"`    // ISO 3166 code: ru (default), by, kz, uz, ...
+	CacheFile   string `mapstructure:"cache_file"` // Persistent cache of fetched calendar data
 
 	// Legacy fields for production-calendar type (backward compatibility)
 	APIURL       string `mapstructure:"api_url"`
 	APIToken     string `mapstructure:"api_token"`
 	FallbackFile string `mapstructure:"fallback_file"`
-	Country      string `mapstructure:"country"`
+
+	// Ordered provider chain; when set it replaces type
+	Providers []CalendarProviderConfig `mapstructure:"providers"`
+	Breaker   CalendarBreakerConfig    `mapstructure:"breaker"`
+
+	// Overlays are applied on top of the calendar in order (type ics only)
+	Overlays []CalendarProviderConfig `mapstructure:"overlays"`
+}
+
+// CalendarProviderConfig describes one provider of the calendar chain
+type CalendarProviderConfig struct {
+	Type      string   `mapstructure:"type"`        // isdayoff, xmlcalendar, file, ics, production-calendar, static
+	Name      string   `mapstructure:"name"`        // Shown in logs and calendar doctor (default: type)
+	Timeout   string   `mapstructure:"timeout"`     // Per-call limit, e.g. "5s" (default: none)
+	URL       string   `mapstructure:"url"`         // xmlcalendar URL template (default: calendar.fallback_url) or production-calendar API URL
+	Path      string   `mapstructure:"path"`        // file and ics providers
+	APIToken  string   `mapstructure:"api_token"`   // production-calendar provider
+	Country   string   `mapstructure:"country"`     // Overrides calendar.country
+	Weekdays  []string `mapstructure:"weekdays"`    // static, file and ics provider workdays (default mon–fri)
+	AllDayOff bool     `mapstructure:"all_day_off"` // ics: every all-day event is a day off, not only marked ones
+}
+
+// CalendarBreakerConfig configures circuit breaking of chain providers
+type CalendarBreakerConfig struct {
+	Failures int    `mapstructure:"failures"` // Consecutive failures that open the circuit (default 3)
+	Cooldown string `mapstructure:"cooldown"` // How long an open provider is skipped (default 5m)
 }
 
 // TimeRulesConfig represents time distribution rules
 type TimeRulesConfig struct {
-	TargetHoursPerDay    int                `mapstructure:"target_hours_per_day"`
+	TargetHoursPerDay    float64            `mapstructure:"target_hours_per_day"` // May be fractional, e.g. 7.2 for a 36-hour week
 	DailyTasks           []DailyTaskConfig  `mapstructure:"daily_tasks"`
 	WeeklyTasks          []WeeklyTaskConfig `mapstructure:"weekly_tasks"`
 	BoardTasks           BoardTasksConfig   `mapstructure:"board_tasks"`
 	RandomizationPercent float64            `mapstructure:"randomization_percent"`
+	ActiveStatuses       StatusRulesConfig  `mapstructure:"active_statuses"`
+	IssueRules           []IssueRuleConfig  `mapstructure:"issue_rules"`
+	MinEntryMinutes      float64            `mapstructure:"min_entry_minutes"` // Default minimum size of a distributed entry
+	RoundingMinutes      int                `mapstructure:"rounding_minutes"`  // Worklog granularity: 1, 5, 15 or 30
+	Workday              WorkdayConfig      `mapstructure:"workday"`
+	Schedule             []ScheduleConfig   `mapstructure:"schedule"` // Personal work schedule periods
+	Estimates            EstimatesConfig    `mapstructure:"estimates"`
+}
+
+// EstimatesConfig caps distributed time by the issue estimate in Tracker
+type EstimatesConfig struct {
+	Enabled       bool    `mapstructure:"enabled"`
+	Factor        float64 `mapstructure:"factor"`         // Spent may reach estimate × factor (default 1.5)
+	OverflowIssue string  `mapstructure:"overflow_issue"` // Gets time no candidate can take (required when enabled)
+}
+
+// GetFactor returns how far spent time may exceed the estimate (default 1.5)
+func (c *EstimatesConfig) GetFactor() float64 {
+	if c.Factor <= 0 {
+		return 1.5
+	}
+	return c.Factor
+}
+
+// ScheduleConfig describes a personal work schedule from EffectiveFrom until the next period.
+// Either FTE scales calendar hours, or Hours sets hours per weekday for a full
+// calendar day (weekdays missing from Hours are days off; a working Saturday or Sunday
+// of the calendar missing from Hours gets the average working day of the period).
+type ScheduleConfig struct {
+	EffectiveFrom string             `mapstructure:"effective_from"` // YYYY-MM-DD
+	FTE           float64            `mapstructure:"fte"`            // e.g. 0.5 or 0.8
+	Hours         map[string]float64 `mapstructure:"hours"`          // mon..sun → hours
+}
+
+// WorkdayConfig describes the day window used to lay out worklog start times
+type WorkdayConfig struct {
+	Start        string              `mapstructure:"start"`         // HH:MM, default 10:00
+	End          string              `mapstructure:"end"`           // HH:MM, default 19:00
+	LunchStart   string              `mapstructure:"lunch_start"`   // HH:MM, empty = no lunch break
+	LunchMinutes int                 `mapstructure:"lunch_minutes"` // default 60 when lunch_start is set
+	Meetings     []MeetingSlotConfig `mapstructure:"meetings"`
+}
+
+// MeetingSlotConfig reserves a recurring slot of the workday.
+// When Issue matches a daily task, that task is logged inside the slot.
+type MeetingSlotConfig struct {
+	At      string `mapstructure:"at"` // HH:MM
+	Minutes int    `mapstructure:"minutes"`
+	Issue   string `mapstructure:"issue"`
+}
+
+// IssueRuleConfig tunes distribution for issues matching all given selectors.
+// Rules are checked in order, the first matching rule wins.
+type IssueRuleConfig struct {
+	// Selectors (empty = any)
+	Issue     string `mapstructure:"issue"`
+	Queue     string `mapstructure:"queue"`
+	Type      string `mapstructure:"type"`
+	Tag       string `mapstructure:"tag"`
+	Component string `mapstructure:"component"`
+
+	// Limits
+	Weight           float64 `mapstructure:"weight"`              // Relative share, default 1
+	MaxMinutesPerDay float64 `mapstructure:"max_minutes_per_day"` // 0 = unlimited
+	MaxSharePercent  float64 `mapstructure:"max_share_percent"`   // Max share of the daily target, 0 = unlimited
+	MinEntryMinutes  float64 `mapstructure:"min_entry_minutes"`   // Smaller entries are merged into larger ones
+}
+
+// HasMetadataSelectors reports whether matching needs issue fields beyond the key
+func (r *IssueRuleConfig) HasMetadataSelectors() bool {
+	return r.Type != "" || r.Tag != "" || r.Component != ""
+}
+
+// StatusRulesConfig maps Tracker status keys to "being worked on" semantics.
+// Queue-specific lists replace the global ones for issues of that queue.
+type StatusRulesConfig struct {
+	Active   []string                     `mapstructure:"active"`   // statuses that count as work in progress
+	Inactive []string                     `mapstructure:"inactive"` // known statuses that are not worked on
+	Queues   map[string]QueueStatusConfig `mapstructure:"queues"`   // queue key → overrides
 }
 
-// DailyTaskConfig represents a daily task
+// QueueStatusConfig represents status semantics for a single queue
+type QueueStatusConfig struct {
+	Active   []string `mapstructure:"active"`
+	Inactive []string `mapstructure:"inactive"`
+}
+
+// DailyTaskConfig represents a daily task.
+// Weekdays and EveryNWeeks narrow the days it is logged on; At fixes its start time.
 type DailyTaskConfig struct {
-	Issue       string `mapstructure:"issue"`
-	Minutes     int    `mapstructure:"minutes"`
-	Description string `mapstructure:"description"`
+	Issue       string   `mapstructure:"issue"`
+	Minutes     int      `mapstructure:"minutes"`
+	Description string   `mapstructure:"description"`
+	At          string   `mapstructure:"at"`            // HH:MM, empty = placed by the workday layout
+	Weekdays    []string `mapstructure:"weekdays"`      // mon..sun, empty = every workday
+	EveryNWeeks int      `mapstructure:"every_n_weeks"` // 0/1 = every week
+	StartDate   string   `mapstructure:"start_date"`    // YYYY-MM-DD, week of the first occurrence for every_n_weeks
 }
 
 // WeeklyTaskConfig represents a weekly task
@@ -91,7 +247,93 @@ type IAMConfig struct {
 
 // StateConfig represents state storage configuration
 type StateConfig struct {
-	WeeklyScheduleFile string `mapstructure:"weekly_schedule_file"`
+	WeeklyScheduleFile   string `mapstructure:"weekly_schedule_file"`
+	WeeklyRetentionWeeks int    `mapstructure:"weekly_retention_weeks"` // How many weeks of selections to keep (default 12)
+	LockFile             string `mapstructure:"lock_file"`              // Single-instance lock, default sync.lock next to the weekly schedule
+	Backend              string `mapstructure:"backend"`                // "bolt" (default) or "json"
+	DBFile               string `mapstructure:"db_file"`                // Database for the bolt backend, default bot.db next to the weekly schedule
+}
+
+// TimeOffConfig represents personal time off (vacation, sick leave, days off)
+type TimeOffConfig struct {
+	File          string               `mapstructure:"file"`           // Managed by `timeoff add/remove`, default ./state/time_off.json
+	Ranges        []TimeOffRangeConfig `mapstructure:"ranges"`         // Static ranges in addition to the file
+	AbsenceIssue  string               `mapstructure:"absence_issue"`  // Log time off to this issue instead of skipping the day
+	AbsenceIssues map[string]string    `mapstructure:"absence_issues"` // kind → issue, overrides absence_issue
+}
+
+// TimeOffRangeConfig represents a time off range, both ends inclusive
+type TimeOffRangeConfig struct {
+	From string `mapstructure:"from"` // YYYY-MM-DD
+	To   string `mapstructure:"to"`   // YYYY-MM-DD, default = from
+	Kind string `mapstructure:"kind"` // vacation, sick, dayoff
+	Note string `mapstructure:"note"`
+}
+
+// GetFile returns the time off file path
+func (c *TimeOffConfig) GetFile() string {
+	if c.File == "" {
+		return "./state/time_off.json"
+	}
+	return c.File
+}
+
+// AbsenceIssueFor returns the issue absence of the kind is logged to, or "" to skip the day
+func (c *TimeOffConfig) AbsenceIssueFor(kind string) string {
+	if issue, ok := c.AbsenceIssues[strings.ToLower(kind)]; ok {
+		return issue
+	}
+	return c.AbsenceIssue
+}
+
+// MeetingsConfig maps meetings of an exported work calendar (.ics) to issues.
+// Rules are tried in order; a meeting no rule matches goes to the first issue key
+// found in its description.
+type MeetingsConfig struct {
+	File   string              `mapstructure:"file"`   // .ics export of the work calendar; empty disables meetings
+	Rules  []MeetingRuleConfig `mapstructure:"rules"`  // Explicit meeting → issue mapping
+	Queues []string            `mapstructure:"queues"` // Only keys of these queues are taken from descriptions (default: any)
+}
+
+// MeetingRuleConfig maps meetings by title and/or attendee; all set conditions must match
+type MeetingRuleConfig struct {
+	Issue    string `mapstructure:"issue"`
+	Title    string `mapstructure:"title"`    // Regular expression matched against the meeting title
+	Attendee string `mapstructure:"attendee"` // E-mail of an attendee or the organizer
+}
+
+// ActivityConfig configures git activity: in-progress issues with the user's
+// commits on a day get most of the remaining time, split by activity
+type ActivityConfig struct {
+	Repos    []string `mapstructure:"repos"`     // Local git repositories; empty disables activity
+	Authors  []string `mapstructure:"authors"`   // Commit author e-mails (default: user.email of each repository)
+	WeightBy string   `mapstructure:"weight_by"` // commits (default) or lines
+	Share    float64  `mapstructure:"share"`     // Part of the remaining time split by activity (default 0.8)
+	Queues   []string `mapstructure:"queues"`    // Only keys of these queues count (default: any)
+}
+
+// GetShare returns the part of the remaining time split by activity (default 0.8)
+func (a *ActivityConfig) GetShare() float64 {
+	if a.Share <= 0 {
+		return 0.8
+	}
+	return a.Share
+}
+
+// Validate checks a meeting rule
+func (r *MeetingRuleConfig) Validate() error {
+	if r.Issue == "" {
+		return fmt.Errorf("issue is required")
+	}
+	if r.Title == "" && r.Attendee == "" {
+		return fmt.Errorf("title or attendee is required")
+	}
+	if r.Title != "" {
+		if _, err := regexp.Compile(r.Title); err != nil {
+			return fmt.Errorf("title: %w", err)
+		}
+	}
+	return nil
 }
 
 // Load loads configuration from file
@@ -139,8 +381,36 @@ func (c *Config) Validate() error {
 	if c.Tracker.APIEndpoint == "" {
 		return fmt.Errorf("tracker.api_endpoint is required")
 	}
-	if c.Tracker.BoardID <= 0 {
-		return fmt.Errorf("tracker.board_id must be positive")
+	if len(c.Tracker.Sources) == 0 && c.Tracker.BoardID <= 0 {
+		return fmt.Errorf("tracker.board_id must be positive (or set tracker.sources)")
+	}
+	sourceNames := make(map[string]bool)
+	totalShare := 0.0
+	for i, src := range c.Tracker.Sources {
+		if (src.Board > 0) == (src.Queue != "") {
+			return fmt.Errorf("tracker.sources[%d]: set either board or queue", i)
+		}
+		if src.Board < 0 {
+			return fmt.Errorf("tracker.sources[%d].board must be positive", i)
+		}
+		if src.Weight < 0 {
+			return fmt.Errorf("tracker.sources[%d].weight must be non-negative", i)
+		}
+		if src.Share < 0 || src.Share > 1 {
+			return fmt.Errorf("tracker.sources[%d].share must be between 0 and 1", i)
+		}
+		if src.Share > 0 && src.Weight > 0 {
+			return fmt.Errorf("tracker.sources[%d]: set either weight or share", i)
+		}
+		name := src.GetName()
+		if sourceNames[name] {
+			return fmt.Errorf("tracker.sources[%d]: duplicate source name %q", i, name)
+		}
+		sourceNames[name] = true
+		totalShare += src.Share
+	}
+	if totalShare > 1 {
+		return fmt.Errorf("tracker.sources: shares add up to %.2f, must not exceed 1", totalShare)
 	}
 	if c.Tracker.IssuesQuery == "" {
 		return fmt.Errorf("tracker.issues_query is required")
@@ -152,11 +422,36 @@ func (c *Config) Validate() error {
 		calType = "isdayoff" // Default to isdayoff
 	}
 
+	if len(c.Calendar.Providers) > 0 {
+		calType = "providers"
+	}
+
 	switch calType {
+	case "providers":
+		for i := range c.Calendar.Providers {
+			provider := &c.Calendar.Providers[i]
+			if err := provider.Validate(); err != nil {
+				return fmt.Errorf("calendar.providers[%d]: %w", i, err)
+			}
+			if provider.Type == "xmlcalendar" && provider.URL == "" && c.Calendar.FallbackURL == "" {
+				return fmt.Errorf("calendar.providers[%d]: url or calendar.fallback_url is required for xmlcalendar", i)
+			}
+		}
+		if !validCountry(c.Calendar.GetCountry()) {
+			return fmt.Errorf("calendar.country must be a two-letter country code, got %q", c.Calendar.Country)
+		}
+		if c.Calendar.Breaker.Cooldown != "" {
+			if _, err := time.ParseDuration(c.Calendar.Breaker.Cooldown); err != nil {
+				return fmt.Errorf("calendar.breaker.cooldown: %w", err)
+			}
+		}
 	case "isdayoff":
 		if c.Calendar.FallbackURL == "" {
 			return fmt.Errorf("calendar.fallback_url is required for isdayoff type")
 		}
+		if !validCountry(c.Calendar.GetCountry()) {
+			return fmt.Errorf("calendar.country must be a two-letter country code, got %q", c.Calendar.Country)
+		}
 	case "production-calendar":
 		if c.Calendar.APIURL == "" {
 			return fmt.Errorf("calendar.api_url is required for production-calendar type")
@@ -171,14 +466,75 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("calendar.type must be 'isdayoff' or 'production-calendar', got '%s'", calType)
 	}
 
+	for i := range c.Calendar.Overlays {
+		overlay := &c.Calendar.Overlays[i]
+		if overlay.Type != "ics" {
+			return fmt.Errorf("calendar.overlays[%d]: only ics overlays are supported, got %q", i, overlay.Type)
+		}
+		if err := overlay.Validate(); err != nil {
+			return fmt.Errorf("calendar.overlays[%d]: %w", i, err)
+		}
+	}
+
 	// Validate TimeRules config
-	if c.TimeRules.TargetHoursPerDay <= 0 {
-		return fmt.Errorf("time_rules.target_hours_per_day must be positive")
+	if c.TimeRules.TargetHoursPerDay <= 0 || c.TimeRules.TargetHoursPerDay > 24 {
+		return fmt.Errorf("time_rules.target_hours_per_day must be between 0 and 24")
 	}
 	if c.TimeRules.RandomizationPercent < 0 || c.TimeRules.RandomizationPercent > 100 {
 		return fmt.Errorf("time_rules.randomization_percent must be between 0 and 100")
 	}
 
+	if err := c.TimeRules.Workday.Validate(); err != nil {
+		return err
+	}
+	for i, period := range c.TimeRules.Schedule {
+		if _, err := time.Parse("2006-01-02", period.EffectiveFrom); err != nil {
+			return fmt.Errorf("time_rules.schedule[%d].effective_from must be YYYY-MM-DD: %w", i, err)
+		}
+		if period.FTE != 0 && len(period.Hours) > 0 {
+			return fmt.Errorf("time_rules.schedule[%d]: set either fte or hours, not both", i)
+		}
+		if period.FTE < 0 {
+			return fmt.Errorf("time_rules.schedule[%d].fte must be non-negative", i)
+		}
+		for day, hours := range period.Hours {
+			if _, err := dateutil.ParseWeekday(day); err != nil {
+				return fmt.Errorf("time_rules.schedule[%d].hours: %w", i, err)
+			}
+			if hours < 0 || hours > 24 {
+				return fmt.Errorf("time_rules.schedule[%d].hours.%s must be between 0 and 24", i, day)
+			}
+		}
+	}
+	for i := range c.TimeRules.DailyTasks {
+		if err := c.TimeRules.DailyTasks[i].Validate(); err != nil {
+			return fmt.Errorf("time_rules.daily_tasks[%d]: %w", i, err)
+		}
+	}
+	switch c.TimeRules.RoundingMinutes {
+	case 0, 1, 5, 15, 30:
+	default:
+		return fmt.Errorf("time_rules.rounding_minutes must be 1, 5, 15 or 30, got %d", c.TimeRules.RoundingMinutes)
+	}
+	if c.TimeRules.MinEntryMinutes < 0 {
+		return fmt.Errorf("time_rules.min_entry_minutes must be non-negative")
+	}
+	for i, rule := range c.TimeRules.IssueRules {
+		if rule.Weight < 0 || rule.MaxMinutesPerDay < 0 || rule.MinEntryMinutes < 0 {
+			return fmt.Errorf("time_rules.issue_rules[%d]: weight and minute limits must be non-negative", i)
+		}
+		if rule.MaxSharePercent < 0 || rule.MaxSharePercent > 100 {
+			return fmt.Errorf("time_rules.issue_rules[%d].max_share_percent must be between 0 and 100", i)
+		}
+	}
+
+	if c.TimeRules.Estimates.Factor != 0 && c.TimeRules.Estimates.Factor < 1 {
+		return fmt.Errorf("time_rules.estimates.factor must be at least 1")
+	}
+	if c.TimeRules.Estimates.Enabled && c.TimeRules.Estimates.OverflowIssue == "" {
+		return fmt.Errorf("time_rules.estimates.overflow_issue is required when estimates are enabled")
+	}
+
 	// Validate BoardTasks config
 	if c.TimeRules.BoardTasks.Enabled {
 		if c.TimeRules.BoardTasks.BaseMinutesPerDay < 0 {
@@ -195,6 +551,47 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate State config
+	switch c.State.GetBackend() {
+	case "bolt", "json":
+	default:
+		return fmt.Errorf("state.backend must be 'bolt' or 'json', got '%s'", c.State.Backend)
+	}
+
+	// Validate TimeOff config
+	for i, r := range c.TimeOff.Ranges {
+		from, err := time.Parse("2006-01-02", r.From)
+		if err != nil {
+			return fmt.Errorf("time_off.ranges[%d].from must be YYYY-MM-DD: %w", i, err)
+		}
+		if r.To != "" {
+			to, err := time.Parse("2006-01-02", r.To)
+			if err != nil {
+				return fmt.Errorf("time_off.ranges[%d].to must be YYYY-MM-DD: %w", i, err)
+			}
+			if to.Before(from) {
+				return fmt.Errorf("time_off.ranges[%d]: to is before from", i)
+			}
+		}
+	}
+
+	// Validate Meetings config
+	for i := range c.Meetings.Rules {
+		if err := c.Meetings.Rules[i].Validate(); err != nil {
+			return fmt.Errorf("meetings.rules[%d]: %w", i, err)
+		}
+	}
+
+	// Validate Activity config
+	switch c.Activity.WeightBy {
+	case "", "commits", "lines":
+	default:
+		return fmt.Errorf("activity.weight_by must be 'commits' or 'lines', got '%s'", c.Activity.WeightBy)
+	}
+	if c.Activity.Share < 0 || c.Activity.Share > 1 {
+		return fmt.Errorf("activity.share must be between 0 and 1")
+	}
+
 	// Validate IAM config
 	if c.IAM.CLICommand == "" {
 		return fmt.Errorf("iam.cli_command is required")
@@ -203,6 +600,239 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// Validate checks schedule fields of a daily task
+func (d *DailyTaskConfig) Validate() error {
+	if d.At != "" {
+		if _, err := dateutil.ParseClock(d.At); err != nil {
+			return fmt.Errorf("at: %w", err)
+		}
+	}
+	for _, day := range d.Weekdays {
+		if _, err := dateutil.ParseWeekday(day); err != nil {
+			return fmt.Errorf("weekdays: %w", err)
+		}
+	}
+	if d.EveryNWeeks < 0 {
+		return fmt.Errorf("every_n_weeks must be non-negative")
+	}
+	if d.StartDate != "" {
+		if _, err := time.Parse("2006-01-02", d.StartDate); err != nil {
+			return fmt.Errorf("start_date must be YYYY-MM-DD: %w", err)
+		}
+	}
+	return nil
+}
+
+// Validate checks a calendar provider entry
+func (p *CalendarProviderConfig) Validate() error {
+	switch p.Type {
+	case "isdayoff", "xmlcalendar", "static":
+	case "file", "ics":
+		if p.Path == "" {
+			return fmt.Errorf("path is required for %s provider", p.Type)
+		}
+	case "production-calendar":
+		if p.URL == "" || p.APIToken == "" {
+			return fmt.Errorf("url and api_token are required for production-calendar provider")
+		}
+	default:
+		return fmt.Errorf("unknown provider type %q", p.Type)
+	}
+
+	if p.Timeout != "" {
+		if _, err := time.ParseDuration(p.Timeout); err != nil {
+			return fmt.Errorf("timeout: %w", err)
+		}
+	}
+	if p.Country != "" && !validCountry(strings.ToLower(p.Country)) {
+		return fmt.Errorf("country must be a two-letter country code, got %q", p.Country)
+	}
+	for _, day := range p.Weekdays {
+		if _, err := dateutil.ParseWeekday(day); err != nil {
+			return fmt.Errorf("weekdays: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetName returns the provider name used in logs and reports
+func (p *CalendarProviderConfig) GetName() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.Type
+}
+
+// GetTimeout returns the per-call timeout (0 = no limit)
+func (p *CalendarProviderConfig) GetTimeout() time.Duration {
+	timeout, err := time.ParseDuration(p.Timeout)
+	if err != nil {
+		return 0
+	}
+	return timeout
+}
+
+// GetCooldown returns how long a failing provider is skipped (default 5m)
+func (b *CalendarBreakerConfig) GetCooldown() time.Duration {
+	cooldown, err := time.ParseDuration(b.Cooldown)
+	if err != nil || cooldown <= 0 {
+		return 5 * time.Minute
+	}
+	return cooldown
+}
+
+// validCountry reports whether code is a lower-case two-letter country code
+func validCountry(code string) bool {
+	return len(code) == 2 && strings.Trim(code, "abcdefghijklmnopqrstuvwxyz") == ""
+}
+
+// Validate checks workday clock values
+func (w *WorkdayConfig) Validate() error {
+	clocks := map[string]string{
+		"start":       w.Start,
+		"end":         w.End,
+		"lunch_start": w.LunchStart,
+	}
+	for name, value := range clocks {
+		if value == "" {
+			continue
+		}
+		if _, err := dateutil.ParseClock(value); err != nil {
+			return fmt.Errorf("time_rules.workday.%s: %w", name, err)
+		}
+	}
+	if w.GetStartMinute() >= w.GetEndMinute() {
+		return fmt.Errorf("time_rules.workday.start must be before end")
+	}
+	for i, slot := range w.Meetings {
+		if _, err := dateutil.ParseClock(slot.At); err != nil {
+			return fmt.Errorf("time_rules.workday.meetings[%d].at: %w", i, err)
+		}
+		if slot.Minutes <= 0 {
+			return fmt.Errorf("time_rules.workday.meetings[%d].minutes must be positive", i)
+		}
+	}
+	return nil
+}
+
+// GetStartMinute returns workday start as minutes since midnight (default 10:00)
+func (w *WorkdayConfig) GetStartMinute() int {
+	return clockOrDefault(w.Start, 10*60)
+}
+
+// GetEndMinute returns workday end as minutes since midnight (default 19:00)
+func (w *WorkdayConfig) GetEndMinute() int {
+	return clockOrDefault(w.End, 19*60)
+}
+
+// GetLunch returns lunch start (minutes since midnight) and duration; ok=false when not configured
+func (w *WorkdayConfig) GetLunch() (start, minutes int, ok bool) {
+	if w.LunchStart == "" {
+		return 0, 0, false
+	}
+	start, err := dateutil.ParseClock(w.LunchStart)
+	if err != nil {
+		return 0, 0, false
+	}
+	minutes = w.LunchMinutes
+	if minutes <= 0 {
+		minutes = 60
+	}
+	return start, minutes, true
+}
+
+func clockOrDefault(value string, fallback int) int {
+	if value == "" {
+		return fallback
+	}
+	minute, err := dateutil.ParseClock(value)
+	if err != nil {
+		return fallback
+	}
+	return minute
+}
+
+// GetTargetHoursPerDay returns the length of a full calendar workday in hours (default 8).
+// Schedules are scaled against it on shortened days.
+func (c *TimeRulesConfig) GetTargetHoursPerDay() float64 {
+	if c.TargetHoursPerDay <= 0 {
+		return 8
+	}
+	return c.TargetHoursPerDay
+}
+
+// GetTargetMinutesPerDay returns the full workday length rounded to whole minutes
+func (c *TimeRulesConfig) GetTargetMinutesPerDay() int {
+	return int(math.Round(c.GetTargetHoursPerDay() * 60))
+}
+
+// GetRoundingMinutes returns worklog duration granularity in minutes (default 1)
+func (c *TimeRulesConfig) GetRoundingMinutes() int {
+	if c.RoundingMinutes <= 0 {
+		return 1
+	}
+	return c.RoundingMinutes
+}
+
+// GetWeeklyRetentionWeeks returns how many weeks of weekly selections are kept (default 12)
+func (c *StateConfig) GetWeeklyRetentionWeeks() int {
+	if c.WeeklyRetentionWeeks <= 0 {
+		return 12
+	}
+	return c.WeeklyRetentionWeeks
+}
+
+// GetSources returns the configured sources, or the legacy board_id as the only source
+func (c *TrackerConfig) GetSources() []TrackerSourceConfig {
+	if len(c.Sources) > 0 {
+		return c.Sources
+	}
+	if c.BoardID > 0 {
+		return []TrackerSourceConfig{{Board: c.BoardID}}
+	}
+	return nil
+}
+
+// GetLockFile returns the path of the sync lock file
+func (c *StateConfig) GetLockFile() string {
+	if c.LockFile != "" {
+		return c.LockFile
+	}
+	return filepath.Join(filepath.Dir(c.WeeklyScheduleFile), "sync.lock")
+}
+
+// GetBackend returns the state backend: "bolt" (default) or "json"
+func (c *StateConfig) GetBackend() string {
+	if c.Backend == "" {
+		return "bolt"
+	}
+	return c.Backend
+}
+
+// GetDBFile returns the database path of the bolt backend
+func (c *StateConfig) GetDBFile() string {
+	if c.DBFile != "" {
+		return c.DBFile
+	}
+	return filepath.Join(filepath.Dir(c.WeeklyScheduleFile), "bot.db")
+}
+
+// GetCountry returns the lower-case calendar country code (default "ru")
+func (c *CalendarConfig) GetCountry() string {
+	if c.Country == "" {
+		return "ru"
+	}
+	return strings.ToLower(strings.TrimSpace(c.Country))
+}
+
+// GetCacheFile returns the persistent calendar cache path (default ./state/calendar_cache.json)
+func (c *CalendarConfig) GetCacheFile() string {
+	if c.CacheFile == "" {
+		return "./state/calendar_cache.json"
+	}
+	return c.CacheFile
+}
+
 // GetCacheTTL returns cache TTL duration
 func (c *CalendarConfig) GetCacheTTL() time.Duration {
 	if c.CacheTTL == "" {
@@ -258,4 +888,7 @@ func (c *IAMConfig) GetRefreshInterval() time.Duration {
 func (c *Config) ExpandEnvVars() {
 	c.Tracker.OrgID = os.ExpandEnv(c.Tracker.OrgID)
 	c.Calendar.APIToken = os.ExpandEnv(c.Calendar.APIToken)
+	for i, repo := range c.Activity.Repos {
+		c.Activity.Repos[i] = os.ExpandEnv(repo)
+	}
 }
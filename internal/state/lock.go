@@ -0,0 +1,92 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrLocked is returned when the lock is held by another process
+var ErrLocked = errors.New("state is locked by another process")
+
+// lockRetryInterval is how often a waiting Acquire retries the lock
+const lockRetryInterval = 500 * time.Millisecond
+
+// Lock is an advisory, process-wide file lock. The OS releases it when the
+// process exits, so a crashed run never leaves a stale lock behind.
+type Lock struct {
+	path string
+	file *os.File
+}
+
+// Acquire takes the lock at path. When another process holds it, Acquire retries
+// for up to wait and then returns an error wrapping ErrLocked that describes the holder.
+func Acquire(path string, wait time.Duration) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		err := tryLock(file)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrLocked) {
+			file.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if !time.Now().Before(deadline) {
+			holder := readHolder(path)
+			file.Close()
+			if holder != "" {
+				return nil, fmt.Errorf("%w (%s)", ErrLocked, holder)
+			}
+			return nil, ErrLocked
+		}
+		time.Sleep(lockRetryInterval)
+	}
+
+	// Record the holder for the error message of the next process
+	holder := fmt.Sprintf("pid %d, since %s", os.Getpid(), time.Now().Format(time.RFC3339))
+	if err := file.Truncate(0); err == nil {
+		_, _ = file.WriteAt([]byte(holder+"\n"), 0)
+	}
+
+	return &Lock{path: path, file: file}, nil
+}
+
+// Release unlocks and closes the lock file
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	_ = l.file.Truncate(0)
+	err := unlock(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
+
+// Path returns the lock file path
+func (l *Lock) Path() string {
+	return l.path
+}
+
+func readHolder(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,50 @@
+package state
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAcquire_SecondHolderIsRejected(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync.lock")
+
+	first, err := Acquire(path, 0)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	if _, err := Acquire(path, 0); !errors.Is(err, ErrLocked) {
+		t.Fatalf("second Acquire() error = %v, want ErrLocked", err)
+	}
+
+	if err := first.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+
+	second, err := Acquire(path, 0)
+	if err != nil {
+		t.Fatalf("Acquire() after release error = %v", err)
+	}
+	second.Release()
+}
+
+func TestAcquire_WaitsForRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync.lock")
+
+	first, err := Acquire(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		first.Release()
+	}()
+
+	second, err := Acquire(path, 5*time.Second)
+	if err != nil {
+		t.Fatalf("Acquire() with wait error = %v", err)
+	}
+	second.Release()
+}
@@ -0,0 +1,52 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersionKey is the top-level JSON field holding the document version
+const SchemaVersionKey = "schema_version"
+
+// Migration upgrades a JSON object from schema version N to N+1 in place
+type Migration func(doc map[string]json.RawMessage) error
+
+// Upgrade brings a JSON document to the latest schema version, where
+// migrations[i] upgrades version i to i+1 and the latest version is len(migrations).
+// Documents without schema_version are version 0. It returns the upgraded document
+// and the version it was read at; documents from a newer version are rejected.
+func Upgrade(data []byte, migrations []Migration) ([]byte, int, error) {
+	doc := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse document: %w", err)
+	}
+
+	version := 0
+	if raw, ok := doc[SchemaVersionKey]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, 0, fmt.Errorf("invalid %s: %w", SchemaVersionKey, err)
+		}
+	}
+
+	latest := len(migrations)
+	if version > latest {
+		return nil, version, fmt.Errorf("schema version %d is newer than supported %d", version, latest)
+	}
+	if version == latest {
+		return data, version, nil
+	}
+
+	for v := version; v < latest; v++ {
+		if err := migrations[v](doc); err != nil {
+			return nil, version, fmt.Errorf("migration %d→%d failed: %w", v, v+1, err)
+		}
+	}
+
+	doc[SchemaVersionKey] = json.RawMessage(fmt.Sprintf("%d", latest))
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, version, fmt.Errorf("failed to encode upgraded document: %w", err)
+	}
+
+	return upgraded, version, nil
+}
@@ -0,0 +1,44 @@
+package state
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUpgrade(t *testing.T) {
+	migrations := []Migration{
+		func(doc map[string]json.RawMessage) error {
+			doc["items"] = json.RawMessage(`[]`)
+			return nil
+		},
+		func(doc map[string]json.RawMessage) error {
+			doc["renamed"] = doc["name"]
+			delete(doc, "name")
+			return nil
+		},
+	}
+
+	upgraded, from, err := Upgrade([]byte(`{"name":"x"}`), migrations)
+	if err != nil {
+		t.Fatalf("Upgrade() error = %v", err)
+	}
+	if from != 0 {
+		t.Errorf("from = %d, want 0", from)
+	}
+
+	var doc struct {
+		SchemaVersion int    `json:"schema_version"`
+		Renamed       string `json:"renamed"`
+		Items         []int  `json:"items"`
+	}
+	if err := json.Unmarshal(upgraded, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.SchemaVersion != 2 || doc.Renamed != "x" || doc.Items == nil {
+		t.Errorf("upgraded = %s", upgraded)
+	}
+
+	if _, _, err := Upgrade([]byte(`{"schema_version":3}`), migrations); err == nil {
+		t.Error("Upgrade() of a newer version succeeded, want error")
+	}
+}
@@ -0,0 +1,188 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	bucketWeeks    = []byte("weeks")
+	bucketRuns     = []byte("runs")
+	bucketWorklogs = []byte("worklogs")
+)
+
+// openTimeout limits how long Open waits for another process holding the database
+const openTimeout = 2 * time.Second
+
+// BoltStore is a Store backed by an embedded bbolt database file
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBolt opens (or creates) the database at path
+func OpenBolt(path string) (*BoltStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketWeeks, bucketRuns, bucketWorklogs} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// LoadWeeks returns weekly selections keyed by ISO week
+func (s *BoltStore) LoadWeeks() (map[string]json.RawMessage, error) {
+	weeks := make(map[string]json.RawMessage)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketWeeks).ForEach(func(k, v []byte) error {
+			weeks[string(k)] = append(json.RawMessage(nil), v...)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load weeks: %w", err)
+	}
+	return weeks, nil
+}
+
+// SaveWeeks replaces all stored weekly selections
+func (s *BoltStore) SaveWeeks(weeks map[string]json.RawMessage) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(bucketWeeks); err != nil {
+			return err
+		}
+		bucket, err := tx.CreateBucket(bucketWeeks)
+		if err != nil {
+			return err
+		}
+		for key, value := range weeks {
+			if err := bucket.Put([]byte(key), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save weeks: %w", err)
+	}
+	return nil
+}
+
+// SaveRun creates or updates a run record
+func (s *BoltStore) SaveRun(run *RunRecord) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to encode run: %w", err)
+	}
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketRuns).Put([]byte(run.ID), data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save run %s: %w", run.ID, err)
+	}
+	return nil
+}
+
+// GetRun returns the run with the given ID or ErrNotFound
+func (s *BoltStore) GetRun(id string) (*RunRecord, error) {
+	var run *RunRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketRuns).Get([]byte(id))
+		if data == nil {
+			return ErrNotFound
+		}
+		run = &RunRecord{}
+		return json.Unmarshal(data, run)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run %s: %w", id, err)
+	}
+	return run, nil
+}
+
+// ListRuns returns up to limit most recent runs, newest first (limit <= 0 = all)
+func (s *BoltStore) ListRuns(limit int) ([]RunRecord, error) {
+	runs := []RunRecord{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(bucketRuns).Cursor()
+		for k, v := cursor.Last(); k != nil; k, v = cursor.Prev() {
+			if limit > 0 && len(runs) >= limit {
+				break
+			}
+			var run RunRecord
+			if err := json.Unmarshal(v, &run); err != nil {
+				return fmt.Errorf("run %s: %w", k, err)
+			}
+			runs = append(runs, run)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+	return runs, nil
+}
+
+// AddWorklog records provenance of a created worklog
+func (s *BoltStore) AddWorklog(record *WorklogRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode worklog record: %w", err)
+	}
+	key := []byte(record.RunID + "/" + record.WorklogID)
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketWorklogs).Put(key, data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save worklog record: %w", err)
+	}
+	return nil
+}
+
+// RunWorklogs returns worklogs created by the run
+func (s *BoltStore) RunWorklogs(runID string) ([]WorklogRecord, error) {
+	records := []WorklogRecord{}
+	prefix := []byte(runID + "/")
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(bucketWorklogs).Cursor()
+		for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+			var record WorklogRecord
+			if err := json.Unmarshal(v, &record); err != nil {
+				return fmt.Errorf("worklog %s: %w", k, err)
+			}
+			records = append(records, record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load worklogs of run %s: %w", runID, err)
+	}
+	return records, nil
+}
+
+// Close closes the database
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
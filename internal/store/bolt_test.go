@@ -0,0 +1,92 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T) *BoltStore {
+	t.Helper()
+	st, err := OpenBolt(filepath.Join(t.TempDir(), "state", "bot.db"))
+	if err != nil {
+		t.Fatalf("OpenBolt() error = %v", err)
+	}
+	t.Cleanup(func() { st.Close() })
+	return st
+}
+
+func TestBoltStore_Runs(t *testing.T) {
+	st := openTestStore(t)
+	base := time.Date(2025, 11, 10, 20, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		started := base.AddDate(0, 0, i)
+		run := &RunRecord{ID: NewRunID(started), Command: "sync", StartedAt: started, Outcome: OutcomeRunning}
+		if err := st.SaveRun(run); err != nil {
+			t.Fatal(err)
+		}
+		run.Outcome = OutcomeSuccess
+		run.BackfilledDays = i
+		if err := st.SaveRun(run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runs, err := st.ListRuns(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("ListRuns(2) returned %d runs", len(runs))
+	}
+	if runs[0].BackfilledDays != 2 || runs[1].BackfilledDays != 1 {
+		t.Errorf("ListRuns() not newest first: %+v", runs)
+	}
+	if runs[0].Outcome != OutcomeSuccess {
+		t.Errorf("outcome = %s, want updated %s", runs[0].Outcome, OutcomeSuccess)
+	}
+
+	if _, err := st.GetRun("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetRun(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestBoltStore_WorklogsAndWeeks(t *testing.T) {
+	st := openTestStore(t)
+
+	for _, record := range []*WorklogRecord{
+		{RunID: "run-a", WorklogID: "1", IssueKey: "PROJ-1", Minutes: 30},
+		{RunID: "run-a", WorklogID: "2", IssueKey: "PROJ-2", Minutes: 60},
+		{RunID: "run-b", WorklogID: "3", IssueKey: "PROJ-3", Minutes: 90},
+	} {
+		if err := st.AddWorklog(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := st.RunWorklogs("run-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Errorf("RunWorklogs(run-a) returned %d records, want 2", len(records))
+	}
+
+	weeks := map[string]json.RawMessage{"2025-W46": json.RawMessage(`{"year":2025}`)}
+	if err := st.SaveWeeks(weeks); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.SaveWeeks(map[string]json.RawMessage{"2025-W47": json.RawMessage(`{"year":2025}`)}); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := st.LoadWeeks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loaded["2025-W46"]; ok || len(loaded) != 1 {
+		t.Errorf("LoadWeeks() = %v, want only 2025-W47 after replace", loaded)
+	}
+}
@@ -0,0 +1,85 @@
+// Package store persists bot state and history: weekly selections, run records
+// and provenance of created worklogs.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when a requested record does not exist
+var ErrNotFound = errors.New("record not found")
+
+// Run outcomes
+const (
+	OutcomeRunning = "running"
+	OutcomeSuccess = "success"
+	OutcomeFailed  = "failed"
+)
+
+// RunRecord describes a single sync run
+type RunRecord struct {
+	ID         string    `json:"id"`
+	Command    string    `json:"command"`
+	DryRun     bool      `json:"dry_run"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+	Outcome    string    `json:"outcome"`
+	Error      string    `json:"error,omitempty"`
+
+	// Counters from NormalizationSummary
+	NormalizedDays int     `json:"normalized_days"`
+	TrimmedMinutes float64 `json:"trimmed_minutes"`
+
+	// Counters from BackfillResult
+	BackfilledDays   int     `json:"backfilled_days"`
+	BackfillEntries  int     `json:"backfill_entries"`
+	BackfillMinutes  float64 `json:"backfill_minutes"`
+	WorklogsCreated  int     `json:"worklogs_created"`
+	MinutesLoggedNow float64 `json:"minutes_logged_now"` // logged for today
+}
+
+// WorklogRecord links a worklog created in Tracker to the run that created it
+type WorklogRecord struct {
+	RunID     string    `json:"run_id"`
+	WorklogID string    `json:"worklog_id"`
+	IssueKey  string    `json:"issue_key"`
+	Date      string    `json:"date"` // YYYY-MM-DD the worklog belongs to
+	Start     time.Time `json:"start"`
+	Minutes   float64   `json:"minutes"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store is a persistent backend for bot state and history
+type Store interface {
+	// LoadWeeks returns weekly selections keyed by ISO week ("2025-W45")
+	LoadWeeks() (map[string]json.RawMessage, error)
+
+	// SaveWeeks replaces all stored weekly selections
+	SaveWeeks(weeks map[string]json.RawMessage) error
+
+	// SaveRun creates or updates a run record
+	SaveRun(run *RunRecord) error
+
+	// GetRun returns the run with the given ID or ErrNotFound
+	GetRun(id string) (*RunRecord, error)
+
+	// ListRuns returns up to limit most recent runs, newest first
+	ListRuns(limit int) ([]RunRecord, error)
+
+	// AddWorklog records provenance of a created worklog
+	AddWorklog(record *WorklogRecord) error
+
+	// RunWorklogs returns worklogs created by the run
+	RunWorklogs(runID string) ([]WorklogRecord, error)
+
+	// Close releases the backend
+	Close() error
+}
+
+// NewRunID returns a sortable ID for a run starting at t
+func NewRunID(t time.Time) string {
+	return t.UTC().Format("20060102T150405.000000Z")
+}
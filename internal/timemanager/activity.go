@@ -0,0 +1,52 @@
+package timemanager
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ActivitySource reports per-issue work activity on a date, e.g. commit counts
+type ActivitySource interface {
+	Activity(date time.Time) (map[string]float64, error)
+}
+
+// activityOn returns activity for the date; failures are logged and mean no activity
+func (m *Manager) activityOn(date time.Time) map[string]float64 {
+	if m.activity == nil {
+		return nil
+	}
+
+	activity, err := m.activity.Activity(date)
+	if err != nil {
+		m.logger.Warn("Failed to read activity, distributing without it",
+			zap.Time("date", date),
+			zap.Error(err))
+		return nil
+	}
+
+	if len(activity) > 0 {
+		m.logger.Info("Activity on date",
+			zap.Time("date", date),
+			zap.Any("issues", activity))
+	}
+	return activity
+}
+
+// weightByActivity blends item weights with activity: share of the total weight is
+// split by activity among items that have it, the rest keeps the configured weights.
+// Without activity on any item the weights are left as they are.
+func weightByActivity(items []allocationItem, activity map[string]float64, share float64) {
+	weightSum, activitySum := 0.0, 0.0
+	for _, item := range items {
+		weightSum += item.Weight
+		activitySum += activity[item.IssueKey]
+	}
+	if activitySum <= 0 || weightSum <= 0 {
+		return
+	}
+
+	for i := range items {
+		items[i].Weight = (1-share)*items[i].Weight/weightSum + share*activity[items[i].IssueKey]/activitySum
+	}
+}
@@ -0,0 +1,31 @@
+package timemanager
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWeightByActivity(t *testing.T) {
+	items := []allocationItem{
+		{IssueKey: "PROJ-1", Weight: 1},
+		{IssueKey: "PROJ-2", Weight: 1},
+		{IssueKey: "PROJ-3", Weight: 2},
+	}
+	weightByActivity(items, map[string]float64{"PROJ-2": 3, "PROJ-3": 1, "OTHER-1": 5}, 0.8)
+
+	alloc, _ := allocateWeighted(400, items)
+	want := []float64{20, 260, 120} // 20% by weights (1:1:2), 80% by commits (0:3:1)
+	for i := range want {
+		if math.Abs(alloc[i]-want[i]) > 1e-6 {
+			t.Errorf("alloc = %v, want %v", alloc, want)
+			break
+		}
+	}
+
+	// Without activity on any candidate the weights stay as configured
+	items = []allocationItem{{IssueKey: "PROJ-1", Weight: 1}, {IssueKey: "PROJ-2", Weight: 3}}
+	weightByActivity(items, map[string]float64{"OTHER-1": 5}, 0.8)
+	if items[0].Weight != 1 || items[1].Weight != 3 {
+		t.Errorf("weights = %v, want unchanged", items)
+	}
+}
@@ -0,0 +1,274 @@
+package timemanager
+
+import (
+	"math"
+	"sort"
+
+	"github.com/username/time-tracker-bot/internal/tracker"
+)
+
+// allocationItem describes a single issue competing for minutes
+type allocationItem struct {
+	IssueKey   string
+	Weight     float64
+	MaxMinutes float64 // 0 = unlimited
+	MinMinutes float64 // entries below this size are merged into others
+}
+
+// allocateWeighted splits total minutes proportionally to weights while respecting caps.
+// Capped items are frozen at their cap and the excess is re-spread across the rest
+// (water-filling). Minutes that no item can take are returned as leftover.
+func allocateWeighted(total float64, items []allocationItem) ([]float64, float64) {
+	result := make([]float64, len(items))
+	if total <= 0 || len(items) == 0 {
+		return result, total
+	}
+
+	open := make([]int, 0, len(items))
+	for i, item := range items {
+		if item.Weight > 0 {
+			open = append(open, i)
+		}
+	}
+
+	remaining := total
+	for len(open) > 0 && remaining > 1e-9 {
+		weightSum := 0.0
+		for _, i := range open {
+			weightSum += items[i].Weight
+		}
+
+		// Freeze items whose proportional share would exceed their cap
+		next := make([]int, 0, len(open))
+		consumed := 0.0
+		for _, i := range open {
+			share := remaining * items[i].Weight / weightSum
+			if items[i].MaxMinutes > 0 && share >= items[i].MaxMinutes {
+				result[i] = items[i].MaxMinutes
+				consumed += items[i].MaxMinutes
+				continue
+			}
+			next = append(next, i)
+		}
+
+		if len(next) == len(open) {
+			for _, i := range open {
+				result[i] = remaining * items[i].Weight / weightSum
+			}
+			remaining = 0
+			break
+		}
+
+		remaining -= consumed
+		open = next
+	}
+
+	if remaining < 1e-9 {
+		remaining = 0
+	}
+
+	return result, remaining
+}
+
+// allocateWithMinimums allocates like allocateWeighted, then repeatedly drops the smallest
+// entry that is below its minimum size so its minutes merge into the remaining items.
+// Dropped items get 0 minutes.
+func allocateWithMinimums(total float64, items []allocationItem) ([]float64, float64) {
+	active := make([]bool, len(items))
+	for i := range items {
+		active[i] = true
+	}
+
+	for {
+		subset := make([]allocationItem, 0, len(items))
+		indexes := make([]int, 0, len(items))
+		for i, item := range items {
+			if active[i] {
+				subset = append(subset, item)
+				indexes = append(indexes, i)
+			}
+		}
+
+		alloc, leftover := allocateWeighted(total, subset)
+
+		smallest := -1
+		for j, minutes := range alloc {
+			if subset[j].MinMinutes <= 0 || minutes >= subset[j].MinMinutes {
+				continue
+			}
+			if smallest == -1 || minutes < alloc[smallest] {
+				smallest = j
+			}
+		}
+
+		if smallest == -1 || len(subset) == 1 {
+			result := make([]float64, len(items))
+			for j, i := range indexes {
+				result[i] = alloc[j]
+			}
+			return result, leftover
+		}
+
+		active[indexes[smallest]] = false
+	}
+}
+
+// scaleEntriesToTarget rescales entries so their total equals target, never pushing an
+// entry above its cap. Fixed entries keep their minutes and the rest is scaled to what
+// they leave. When every entry is capped the rest is spread ignoring caps and the
+// returned flag is false.
+func scaleEntriesToTarget(entries []tracker.TimeEntry, target float64, caps map[string]float64) bool {
+	fixed, flexible := splitFixed(entries)
+	if len(fixed) > 0 {
+		for _, entry := range fixed {
+			target -= entry.Minutes
+		}
+		ok := scaleEntriesToTarget(flexible, math.Max(target, 0), caps)
+		mergeFixed(entries, flexible)
+		return ok
+	}
+
+	total := 0.0
+	for _, entry := range entries {
+		total += entry.Minutes
+	}
+	if total <= 0 || len(entries) == 0 {
+		return true
+	}
+
+	items := make([]allocationItem, len(entries))
+	for i, entry := range entries {
+		items[i] = allocationItem{
+			IssueKey:   entry.IssueKey,
+			Weight:     entry.Minutes,
+			MaxMinutes: caps[entry.IssueKey],
+		}
+	}
+
+	alloc, leftover := allocateWeighted(target, items)
+	for i := range entries {
+		entries[i].Minutes = alloc[i]
+	}
+
+	if leftover <= 0 {
+		return true
+	}
+
+	// Caps cannot absorb the target: spread the rest proportionally anyway
+	allocated := target - leftover
+	for i := range entries {
+		if allocated > 0 {
+			entries[i].Minutes += leftover * entries[i].Minutes / allocated
+		} else {
+			entries[i].Minutes += leftover / float64(len(entries))
+		}
+	}
+
+	return false
+}
+
+// dropEmptyEntries removes entries that ended up with no time
+func dropEmptyEntries(entries []tracker.TimeEntry) []tracker.TimeEntry {
+	result := entries[:0]
+	for _, entry := range entries {
+		if entry.Minutes > 0 {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// splitFixed returns copies of the fixed and the flexible entries
+func splitFixed(entries []tracker.TimeEntry) ([]tracker.TimeEntry, []tracker.TimeEntry) {
+	var fixed, flexible []tracker.TimeEntry
+	for _, entry := range entries {
+		if entry.Fixed {
+			fixed = append(fixed, entry)
+		} else {
+			flexible = append(flexible, entry)
+		}
+	}
+	return fixed, flexible
+}
+
+// mergeFixed writes flexible entries back over the non-fixed entries in order
+func mergeFixed(entries, flexible []tracker.TimeEntry) {
+	j := 0
+	for i := range entries {
+		if !entries[i].Fixed {
+			entries[i] = flexible[j]
+			j++
+		}
+	}
+}
+
+// roundEntries rounds entries to multiples of granularity minutes with the largest
+// remainder method, so the integer total equals the rounded target exactly.
+// Minutes that do not fit the granularity go to the largest entry; entries rounded
+// down to zero are dropped. Fixed entries are kept as they are, first.
+func roundEntries(entries []tracker.TimeEntry, targetMinutes float64, granularity int) []tracker.TimeEntry {
+	fixed, flexible := splitFixed(entries)
+	if len(fixed) > 0 {
+		for _, entry := range fixed {
+			targetMinutes -= entry.Minutes
+		}
+		if targetMinutes < 0.5 {
+			return fixed
+		}
+		return append(fixed, roundEntries(flexible, targetMinutes, granularity)...)
+	}
+
+	if len(entries) == 0 {
+		return entries
+	}
+	if granularity <= 0 {
+		granularity = 1
+	}
+
+	target := int(math.Round(targetMinutes))
+	g := float64(granularity)
+
+	units := make([]int, len(entries))
+	remainders := make([]float64, len(entries))
+	usedUnits := 0
+	for i, entry := range entries {
+		exact := entry.Minutes / g
+		units[i] = int(math.Floor(exact))
+		remainders[i] = exact - float64(units[i])
+		usedUnits += units[i]
+	}
+
+	// Hand out the missing units by largest remainder
+	missing := target/granularity - usedUnits
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for k := 0; missing > 0; k++ {
+		units[order[k%len(order)]]++
+		missing--
+	}
+	for k := len(order) - 1; missing < 0; k-- {
+		idx := order[(k%len(order)+len(order))%len(order)]
+		if units[idx] > 0 {
+			units[idx]--
+			missing++
+		}
+	}
+
+	largest := 0
+	for i := range entries {
+		entries[i].Minutes = float64(units[i] * granularity)
+		if entries[i].Minutes > entries[largest].Minutes {
+			largest = i
+		}
+	}
+
+	// Target not divisible by granularity: the rest goes to the largest entry
+	entries[largest].Minutes += float64(target % granularity)
+
+	return dropEmptyEntries(entries)
+}
@@ -0,0 +1,186 @@
+package timemanager
+
+import (
+	"math"
+	"testing"
+
+	"github.com/username/time-tracker-bot/internal/tracker"
+)
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func TestAllocateWeighted(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        float64
+		items        []allocationItem
+		want         []float64
+		wantLeftover float64
+	}{
+		{
+			name:  "equal weights",
+			total: 300,
+			items: []allocationItem{{Weight: 1}, {Weight: 1}, {Weight: 1}},
+			want:  []float64{100, 100, 100},
+		},
+		{
+			name:  "weights",
+			total: 400,
+			items: []allocationItem{{Weight: 3}, {Weight: 1}},
+			want:  []float64{300, 100},
+		},
+		{
+			name:  "cap redistributes excess",
+			total: 420,
+			items: []allocationItem{{Weight: 1, MaxMinutes: 60}, {Weight: 1}, {Weight: 1}},
+			want:  []float64{60, 180, 180},
+		},
+		{
+			name:         "all capped leaves leftover",
+			total:        300,
+			items:        []allocationItem{{Weight: 1, MaxMinutes: 100}, {Weight: 1, MaxMinutes: 50}},
+			want:         []float64{100, 50},
+			wantLeftover: 150,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, leftover := allocateWeighted(tt.total, tt.items)
+			for i := range tt.want {
+				if math.Abs(got[i]-tt.want[i]) > 1e-6 {
+					t.Errorf("allocation[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+			if math.Abs(leftover-tt.wantLeftover) > 1e-6 {
+				t.Errorf("leftover = %v, want %v", leftover, tt.wantLeftover)
+			}
+		})
+	}
+}
+
+func TestAllocateWithMinimums_MergesSmallEntries(t *testing.T) {
+	items := []allocationItem{
+		{IssueKey: "A", Weight: 10, MinMinutes: 30},
+		{IssueKey: "B", Weight: 10, MinMinutes: 30},
+		{IssueKey: "C", Weight: 1, MinMinutes: 30},
+	}
+
+	got, leftover := allocateWithMinimums(210, items)
+
+	if got[2] != 0 {
+		t.Errorf("small entry C = %v, want merged (0)", got[2])
+	}
+	if math.Abs(sum(got)-210) > 1e-6 || leftover != 0 {
+		t.Errorf("total = %v (leftover %v), want 210", sum(got), leftover)
+	}
+}
+
+func TestScaleEntriesToTarget_RespectsCaps(t *testing.T) {
+	entries := []tracker.TimeEntry{
+		{IssueKey: "DAILY", Minutes: 30},
+		{IssueKey: "BIG", Minutes: 200},
+		{IssueKey: "OTHER", Minutes: 200},
+	}
+
+	ok := scaleEntriesToTarget(entries, 480, map[string]float64{"BIG": 210})
+	if !ok {
+		t.Fatal("scaleEntriesToTarget() reported caps exceeded")
+	}
+
+	total := 0.0
+	for _, e := range entries {
+		total += e.Minutes
+	}
+	if math.Abs(total-480) > 1e-6 {
+		t.Errorf("total = %v, want 480", total)
+	}
+	if entries[1].Minutes > 210+1e-6 {
+		t.Errorf("BIG = %v, want <= 210", entries[1].Minutes)
+	}
+}
+
+func TestRoundEntries(t *testing.T) {
+	tests := []struct {
+		name        string
+		minutes     []float64
+		target      float64
+		granularity int
+		want        []float64
+	}{
+		{
+			name:        "minute granularity keeps total",
+			minutes:     []float64{29.7, 160.2, 160.2, 129.9},
+			target:      480,
+			granularity: 1,
+			want:        []float64{30, 160, 160, 130},
+		},
+		{
+			name:        "15 minutes largest remainder",
+			minutes:     []float64{100, 100, 100, 180},
+			target:      480,
+			granularity: 15,
+			want:        []float64{105, 105, 90, 180},
+		},
+		{
+			name:        "target not divisible by granularity",
+			minutes:     []float64{216, 216},
+			target:      432,
+			granularity: 30,
+			want:        []float64{222, 210},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := make([]tracker.TimeEntry, len(tt.minutes))
+			for i, m := range tt.minutes {
+				entries[i] = tracker.TimeEntry{IssueKey: string(rune('A' + i)), Minutes: m}
+			}
+
+			got := roundEntries(entries, tt.target, tt.granularity)
+
+			total := 0.0
+			for i, e := range got {
+				total += e.Minutes
+				if e.Minutes != tt.want[i] {
+					t.Errorf("entry %s = %v, want %v", e.IssueKey, e.Minutes, tt.want[i])
+				}
+			}
+			if total != tt.target {
+				t.Errorf("total = %v, want %v", total, tt.target)
+			}
+		})
+	}
+}
+
+func TestFixedEntriesKeepMinutes(t *testing.T) {
+	entries := []tracker.TimeEntry{
+		{IssueKey: "DEV-1", Minutes: 100},
+		{IssueKey: "MEET-1", Minutes: 25, Fixed: true},
+		{IssueKey: "DEV-2", Minutes: 100},
+	}
+
+	scaleEntriesToTarget(entries, 425, nil)
+	if entries[0].Minutes != 200 || entries[1].Minutes != 25 || entries[2].Minutes != 200 {
+		t.Fatalf("scaleEntriesToTarget() = %v, want 200/25/200", entries)
+	}
+
+	rounded := roundEntries(entries, 425, 15)
+	total := 0.0
+	for _, entry := range rounded {
+		total += entry.Minutes
+		if entry.IssueKey == "MEET-1" && entry.Minutes != 25 {
+			t.Errorf("fixed entry rounded to %v, want 25", entry.Minutes)
+		}
+	}
+	if total != 425 {
+		t.Errorf("rounded total = %v, want 425", total)
+	}
+}
@@ -3,6 +3,7 @@ package timemanager
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/username/time-tracker-bot/internal/tracker"
@@ -11,8 +12,10 @@ import (
 
 // StatusTimeline represents status changes over time for an issue
 type StatusTimeline struct {
-	IssueKey string
-	Changes  []StatusChange
+	IssueKey  string
+	Changes   []StatusChange
+	Boards    []FieldHistoryChange // changes of the boards field, oldest first
+	Assignees []FieldHistoryChange // changes of the assignee field, oldest first
 }
 
 // StatusChange represents a single status change
@@ -21,7 +24,14 @@ type StatusChange struct {
 	Status    string // "open", "inProgress", "resolved", "closed"
 }
 
-// buildStatusTimeline builds a timeline of status changes from changelog
+// FieldHistoryChange is a change of an issue field as recorded in the changelog
+type FieldHistoryChange struct {
+	Timestamp time.Time
+	From      interface{}
+	To        interface{}
+}
+
+// buildStatusTimeline builds a timeline of status, board and assignee changes from changelog
 func buildStatusTimeline(issueKey string, changelog []tracker.ChangelogEntry) *StatusTimeline {
 	timeline := &StatusTimeline{
 		IssueKey: issueKey,
@@ -30,6 +40,12 @@ func buildStatusTimeline(issueKey string, changelog []tracker.ChangelogEntry) *S
 
 	for _, entry := range changelog {
 		for _, field := range entry.Fields {
+			switch field.Field.ID {
+			case "boards":
+				timeline.Boards = append(timeline.Boards, FieldHistoryChange{Timestamp: entry.UpdatedAt.Time, From: field.From, To: field.To})
+			case "assignee":
+				timeline.Assignees = append(timeline.Assignees, FieldHistoryChange{Timestamp: entry.UpdatedAt.Time, From: field.From, To: field.To})
+			}
 			if field.Field.ID == "status" {
 				// Parse "to" status
 				statusKey := "unknown"
@@ -51,10 +67,62 @@ func buildStatusTimeline(issueKey string, changelog []tracker.ChangelogEntry) *S
 	sort.Slice(timeline.Changes, func(i, j int) bool {
 		return timeline.Changes[i].Timestamp.Before(timeline.Changes[j].Timestamp)
 	})
+	sortFieldHistory(timeline.Boards)
+	sortFieldHistory(timeline.Assignees)
 
 	return timeline
 }
 
+func sortFieldHistory(changes []FieldHistoryChange) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Timestamp.Before(changes[j].Timestamp)
+	})
+}
+
+// fieldValueOnDate returns the field value on the date: the value set by the last
+// change on or before the date, or the value before the first change. ok is false
+// when the field never changed, so the changelog tells nothing about it.
+func fieldValueOnDate(changes []FieldHistoryChange, date time.Time) (interface{}, bool) {
+	if len(changes) == 0 {
+		return nil, false
+	}
+
+	endOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+	value := changes[0].From
+	for _, change := range changes {
+		if !change.Timestamp.Before(endOfDay) {
+			break
+		}
+		value = change.To
+	}
+	return value, true
+}
+
+// BoardsOnDate returns the boards field value on the date; see fieldValueOnDate
+func (t *StatusTimeline) BoardsOnDate(date time.Time) (interface{}, bool) {
+	return fieldValueOnDate(t.Boards, date)
+}
+
+// AssigneeOnDate returns the assignee ID on the date, empty when unassigned.
+// ok is false when the assignee never changed.
+func (t *StatusTimeline) AssigneeOnDate(date time.Time) (string, bool) {
+	value, ok := fieldValueOnDate(t.Assignees, date)
+	if !ok {
+		return "", false
+	}
+	user, isMap := value.(map[string]interface{})
+	if !isMap {
+		return "", true
+	}
+	switch id := user["id"].(type) {
+	case string:
+		return id, true
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64), true
+	}
+	return "", true
+}
+
 // StatusOnDate returns the status of the issue on a specific date
 func (t *StatusTimeline) StatusOnDate(date time.Time) string {
 	if len(t.Changes) == 0 {
@@ -83,6 +151,11 @@ func (t *StatusTimeline) StatusOnDate(date time.Time) string {
 	return currentStatus
 }
 
+// IsActiveOnDate reports whether the issue was being worked on at the date according to rules
+func (t *StatusTimeline) IsActiveOnDate(date time.Time, rules *StatusRules) bool {
+	return rules.IsActive(t.IssueKey, t.StatusOnDate(date))
+}
+
 // extractUniqueIssueKeys extracts unique issue keys from worklogs
 func extractUniqueIssueKeys(worklogs []tracker.Worklog) []string {
 	keysMap := make(map[string]bool)
@@ -173,7 +246,7 @@ func (m *Manager) findMissingWorkdays(from, to time.Time) ([]time.Time, error) {
 	// Iterate through each day in the period
 	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
 		// Check if it's a working day
-		isWorkday, targetHours, err := m.calendar.IsWorkday(d)
+		isWorkday, targetMinutes, err := m.dayTarget(d)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check if %s is workday: %w", d.Format("2006-01-02"), err)
 		}
@@ -194,8 +267,6 @@ func (m *Manager) findMissingWorkdays(from, to time.Time) ([]time.Time, error) {
 			return nil, fmt.Errorf("failed to get worked time for %s: %w", d.Format("2006-01-02"), err)
 		}
 
-		targetMinutes := float64(targetHours * 60)
-
 		// If worked less than target, it's a missing day
 		if workedMinutes < targetMinutes {
 			missingDays = append(missingDays, d)
@@ -216,17 +287,16 @@ func (m *Manager) NormalizeWorkdaysRange(from, to time.Time, dryRun bool) (*Norm
 	}
 
 	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
-		isWorkday, targetHours, err := m.calendar.IsWorkday(d)
+		isWorkday, targetMinutes, err := m.dayTarget(d)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check if %s is workday: %w", d.Format("2006-01-02"), err)
 		}
-		if !isWorkday || targetHours == 0 {
+		if !isWorkday {
 			continue
 		}
 
 		summary.ProcessedDays++
 
-		targetMinutes := float64(targetHours * 60)
 		workedMinutes, err := m.trackerClient.GetWorkedMinutesToday(d)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get worked time for %s: %w", d.Format("2006-01-02"), err)
@@ -264,7 +334,14 @@ func (m *Manager) buildStatusTimelines(from, to time.Time) (map[string]*StatusTi
 		return nil, fmt.Errorf("failed to collect relevant issues: %w", err)
 	}
 
+	return m.loadTimelines(issueKeys), nil
+}
+
+// loadTimelines builds status timelines of the issues from their changelogs. Issues
+// never on a configured board or queue, such as time logged to other teams, are left out.
+func (m *Manager) loadTimelines(issueKeys []string) map[string]*StatusTimeline {
 	timelines := make(map[string]*StatusTimeline, len(issueKeys))
+	scope := m.runScope()
 
 	for _, issueKey := range issueKeys {
 		changelog, err := m.trackerClient.GetChangelog(issueKey)
@@ -272,14 +349,52 @@ func (m *Manager) buildStatusTimelines(from, to time.Time) (map[string]*StatusTi
 			m.logger.Warn(fmt.Sprintf("failed to load changelog for %s: %v", issueKey, err))
 			continue
 		}
+		if !m.wasEverInScope(scope, issueKey, changelog) {
+			m.logger.Debug("Issue was never on a configured board or queue, skipped",
+				zap.String("issue", issueKey))
+			continue
+		}
 		timelines[issueKey] = buildStatusTimeline(issueKey, changelog)
 	}
 
-	return timelines, nil
+	if m.issueRules.NeedsMetadata() || m.config.TimeRules.Estimates.Enabled {
+		m.loadIssueMetadata(issueKeys)
+	}
+
+	m.unknownStatuses = m.statusRules.UnknownStatuses(timelines)
+	for queue, statuses := range m.unknownStatuses {
+		m.logger.Warn("Unknown status keys found in changelogs, add them to time_rules.active_statuses",
+			zap.String("queue", queue),
+			zap.Strings("statuses", statuses))
+	}
+
+	return timelines
+}
+
+// loadIssueMetadata loads type, tags and components used by issue rules, and estimates
+func (m *Manager) loadIssueMetadata(issueKeys []string) {
+	missing := []string{}
+	for _, key := range issueKeys {
+		if _, ok := m.issueMeta[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	issues, err := m.trackerClient.GetIssues(missing)
+	if err != nil {
+		m.logger.Warn("Failed to load issue metadata, type/tag/component rules will not match",
+			zap.Error(err))
+		return
+	}
+
+	m.rememberIssues(issues)
 }
 
 // issuesInProgressOnDate возвращает список задач, которые были в работе в указанную дату.
-func issuesInProgressOnDate(date time.Time, timelines map[string]*StatusTimeline) []string {
+func issuesInProgressOnDate(date time.Time, timelines map[string]*StatusTimeline, rules *StatusRules) []string {
 	if len(timelines) == 0 {
 		return nil
 	}
@@ -291,8 +406,7 @@ func issuesInProgressOnDate(date time.Time, timelines map[string]*StatusTimeline
 			continue
 		}
 
-		status := timeline.StatusOnDate(date)
-		if status == "inProgress" || status == "open" || status == "" {
+		if timeline.IsActiveOnDate(date, rules) {
 			result = append(result, issueKey)
 		}
 	}
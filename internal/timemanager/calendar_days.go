@@ -0,0 +1,60 @@
+package timemanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/calendar"
+)
+
+// calendarDays answers per-day questions from whole months, so loops over a date
+// range cost one calendar lookup per month instead of one per day
+type calendarDays struct {
+	calendar calendar.Calendar
+	months   map[string]*calendar.MonthInfo // "YYYY-MM"
+}
+
+func newCalendarDays(cal calendar.Calendar) *calendarDays {
+	return &calendarDays{
+		calendar: cal,
+		months:   make(map[string]*calendar.MonthInfo),
+	}
+}
+
+// Workday returns whether the date is a working day and its working minutes.
+// Days the month does not list are asked for directly.
+func (c *calendarDays) Workday(ctx context.Context, date time.Time) (bool, int, error) {
+	monthInfo, err := c.month(ctx, date.Year(), date.Month())
+	if err != nil {
+		return false, 0, err
+	}
+
+	if day, ok := monthInfo.Day(date); ok {
+		return day.IsWorkday, day.WorkingMinutes, nil
+	}
+	return c.calendar.IsWorkday(ctx, date)
+}
+
+// Stale reports whether the date's month came from an expired cache
+func (c *calendarDays) Stale(ctx context.Context, date time.Time) (bool, error) {
+	monthInfo, err := c.month(ctx, date.Year(), date.Month())
+	if err != nil {
+		return false, err
+	}
+	return monthInfo.Stale, nil
+}
+
+func (c *calendarDays) month(ctx context.Context, year int, month time.Month) (*calendar.MonthInfo, error) {
+	key := fmt.Sprintf("%d-%02d", year, month)
+	if monthInfo, ok := c.months[key]; ok {
+		return monthInfo, nil
+	}
+
+	monthInfo, err := c.calendar.GetMonthInfo(ctx, year, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar for %s: %w", key, err)
+	}
+	c.months[key] = monthInfo
+	return monthInfo, nil
+}
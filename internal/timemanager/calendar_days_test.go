@@ -0,0 +1,85 @@
+package timemanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/calendar"
+)
+
+// countingCalendar is a Mon–Fri calendar that counts lookups
+type countingCalendar struct {
+	monthCalls int
+	dayCalls   int
+	stale      bool
+	off        map[string]bool // "YYYY-MM-DD" holidays on weekdays
+}
+
+func (c *countingCalendar) IsWorkday(ctx context.Context, date time.Time) (bool, int, error) {
+	c.dayCalls++
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false, 0, nil
+	}
+	return true, calendar.DefaultDayMinutes, nil
+}
+
+func (c *countingCalendar) GetMonthInfo(ctx context.Context, year int, month time.Month) (*calendar.MonthInfo, error) {
+	c.monthCalls++
+	info := &calendar.MonthInfo{Year: year, Month: month, Stale: c.stale}
+	for d := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC); d.Month() == month; d = d.AddDate(0, 0, 1) {
+		day := calendar.DayInfo{Date: d, Type: calendar.DayTypeWeekend}
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday && !c.off[d.Format("2006-01-02")] {
+			day = calendar.DayInfo{Date: d, Type: calendar.DayTypeWorkday, WorkingMinutes: calendar.DefaultDayMinutes, IsWorkday: true}
+		}
+		info.Days = append(info.Days, day)
+	}
+	return info, nil
+}
+
+func (c *countingCalendar) GetDayInfo(ctx context.Context, date time.Time) (*calendar.DayInfo, error) {
+	c.dayCalls++
+	return nil, nil
+}
+
+func TestCalendarDays_OneLookupPerMonth(t *testing.T) {
+	cal := &countingCalendar{}
+	days := newCalendarDays(cal)
+
+	workdays := 0
+	for d := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC); d.Before(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); d = d.AddDate(0, 0, 1) {
+		isWorkday, minutes, err := days.Workday(context.Background(), d)
+		if err != nil {
+			t.Fatalf("Workday(%s) error = %v", d.Format("2006-01-02"), err)
+		}
+		if isWorkday {
+			workdays++
+			if minutes != calendar.DefaultDayMinutes {
+				t.Errorf("Workday(%s) minutes = %d, want %d", d.Format("2006-01-02"), minutes, calendar.DefaultDayMinutes)
+			}
+		}
+	}
+
+	if workdays != 43 {
+		t.Errorf("workdays = %d, want 43", workdays)
+	}
+	if cal.monthCalls != 2 || cal.dayCalls != 0 {
+		t.Errorf("calendar calls: %d month / %d day, want 2 / 0", cal.monthCalls, cal.dayCalls)
+	}
+}
+
+func TestRequireFreshCalendar(t *testing.T) {
+	cal := &countingCalendar{}
+	m := &Manager{calendarDays: newCalendarDays(cal), ctx: context.Background()}
+	date := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+
+	if err := m.requireFreshCalendar(date); err != nil {
+		t.Errorf("requireFreshCalendar() with fresh data = %v, want nil", err)
+	}
+
+	m.calendarDays = newCalendarDays(&countingCalendar{stale: true})
+	if err := m.requireFreshCalendar(date); !errors.Is(err, calendar.ErrStaleData) {
+		t.Errorf("requireFreshCalendar() with stale data = %v, want ErrStaleData", err)
+	}
+}
@@ -0,0 +1,63 @@
+package timemanager
+
+import (
+	"strings"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/pkg/dateutil"
+)
+
+// everyNWeeksEpoch is the week counted as the first occurrence when a daily task
+// has every_n_weeks without start_date (Monday, 2024-01-01)
+var everyNWeeksEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// dailyTaskAppliesOn reports whether the daily task is logged on the date.
+// Calendar workday checks are done by the caller.
+func dailyTaskAppliesOn(task config.DailyTaskConfig, date time.Time) bool {
+	if len(task.Weekdays) > 0 {
+		matched := false
+		for _, name := range task.Weekdays {
+			day, err := dateutil.ParseWeekday(name)
+			if err == nil && day == date.Weekday() {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if task.EveryNWeeks > 1 {
+		anchor := everyNWeeksEpoch
+		if task.StartDate != "" {
+			if parsed, err := time.Parse("2006-01-02", strings.TrimSpace(task.StartDate)); err == nil {
+				anchor = parsed
+			}
+		}
+		weeks := dateutil.WeeksBetween(anchor, date)
+		if weeks%task.EveryNWeeks != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dailyTaskStart returns the anchored start of the task on the date, or zero time.
+// The task's own at wins over a workday meeting slot bound to the same issue.
+func dailyTaskStart(task config.DailyTaskConfig, date time.Time, slots map[string]string) time.Time {
+	at := task.At
+	if at == "" {
+		at = slots[task.Issue]
+	}
+	if at == "" {
+		return time.Time{}
+	}
+	minute, err := dateutil.ParseClock(at)
+	if err != nil {
+		return time.Time{}
+	}
+	return dateutil.AtMinute(date, minute)
+}
@@ -0,0 +1,59 @@
+package timemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/config"
+)
+
+func TestDailyTaskAppliesOn(t *testing.T) {
+	retro := config.DailyTaskConfig{
+		Issue:       "PROJ-301",
+		Weekdays:    []string{"fri"},
+		EveryNWeeks: 2,
+		StartDate:   "2025-11-07",
+	}
+	standup := config.DailyTaskConfig{Issue: "PROJ-101", Weekdays: []string{"mon", "wed"}}
+
+	tests := []struct {
+		name string
+		task config.DailyTaskConfig
+		date time.Time
+		want bool
+	}{
+		{"every day", config.DailyTaskConfig{Issue: "PROJ-1"}, time.Date(2025, 11, 11, 0, 0, 0, 0, time.UTC), true},
+		{"listed weekday", standup, time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC), true},
+		{"other weekday", standup, time.Date(2025, 11, 13, 0, 0, 0, 0, time.UTC), false},
+		{"biweekly first week", retro, time.Date(2025, 11, 7, 0, 0, 0, 0, time.UTC), true},
+		{"biweekly off week", retro, time.Date(2025, 11, 14, 0, 0, 0, 0, time.UTC), false},
+		{"biweekly next occurrence", retro, time.Date(2025, 11, 21, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dailyTaskAppliesOn(tt.task, tt.date); got != tt.want {
+				t.Errorf("dailyTaskAppliesOn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDailyTaskStart(t *testing.T) {
+	date := time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC)
+	slots := map[string]string{"PROJ-101": "11:00"}
+
+	task := config.DailyTaskConfig{Issue: "PROJ-101", At: "10:00"}
+	if got := dailyTaskStart(task, date, slots); !got.Equal(clock(date, 10, 0)) {
+		t.Errorf("own at: start = %s, want 10:00", got.Format("15:04"))
+	}
+
+	task.At = ""
+	if got := dailyTaskStart(task, date, slots); !got.Equal(clock(date, 11, 0)) {
+		t.Errorf("meeting slot: start = %s, want 11:00", got.Format("15:04"))
+	}
+
+	if got := dailyTaskStart(config.DailyTaskConfig{Issue: "PROJ-102"}, date, slots); !got.IsZero() {
+		t.Errorf("unanchored: start = %s, want zero", got)
+	}
+}
@@ -0,0 +1,133 @@
+package timemanager
+
+import (
+	"math"
+
+	"github.com/username/time-tracker-bot/internal/tracker"
+	"go.uber.org/zap"
+)
+
+// estimateRoom returns how many more minutes the issue may get before spent time,
+// including time planned by this run, exceeds its estimate × time_rules.estimates.factor.
+// ok is false when caps are off or the issue has no estimate.
+func (m *Manager) estimateRoom(issueKey string) (float64, bool) {
+	if !m.config.TimeRules.Estimates.Enabled {
+		return 0, false
+	}
+	issue := m.issueMeta[issueKey]
+	if issue == nil {
+		return 0, false
+	}
+	estimate := issue.EstimateMinutes()
+	if estimate <= 0 {
+		return 0, false
+	}
+
+	room := estimate*m.config.TimeRules.Estimates.GetFactor() - issue.SpentMinutes() - m.plannedMinutes[issueKey]
+	if room < 0 {
+		room = 0
+	}
+	return room, true
+}
+
+// capByEstimate lowers a per-day cap (0 = unlimited) to the estimate room of the issue
+func (m *Manager) capByEstimate(issueKey string, maxMinutes float64) float64 {
+	room, ok := m.estimateRoom(issueKey)
+	if !ok {
+		return maxMinutes
+	}
+	if maxMinutes == 0 || room < maxMinutes {
+		return room
+	}
+	return maxMinutes
+}
+
+// enforceEstimates moves minutes that would take an issue past its estimate room to
+// time_rules.estimates.overflow_issue. It runs on the final entries of a day, so
+// normalization and rounding never push a capped issue past its estimate. Fixed
+// entries, daily and weekly tasks and the overflow issue itself are not capped.
+func (m *Manager) enforceEstimates(entries []tracker.TimeEntry) []tracker.TimeEntry {
+	overflow := m.config.TimeRules.Estimates.OverflowIssue
+	if !m.config.TimeRules.Estimates.Enabled || overflow == "" {
+		return entries
+	}
+	granularity := float64(m.config.TimeRules.GetRoundingMinutes())
+	fixedTasks := m.fixedTaskKeys()
+
+	used := make(map[string]float64)
+	moved := 0.0
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Fixed || fixedTasks[entry.IssueKey] || entry.IssueKey == overflow {
+			continue
+		}
+		room, ok := m.estimateRoom(entry.IssueKey)
+		if !ok {
+			continue
+		}
+		// Whole rounding steps only, so trimmed entries stay rounded
+		allowed := math.Max(math.Floor(room/granularity)*granularity-used[entry.IssueKey], 0)
+		if entry.Minutes > allowed {
+			moved += entry.Minutes - allowed
+			entry.Minutes = allowed
+		}
+		used[entry.IssueKey] += entry.Minutes
+	}
+	if moved <= 0 {
+		return entries
+	}
+
+	m.logger.Info("Estimate caps exceeded after normalization, moving time to the overflow issue",
+		zap.Float64("minutes", moved),
+		zap.String("issue", overflow))
+	for i := range entries {
+		if entries[i].IssueKey == overflow && !entries[i].Fixed {
+			entries[i].Minutes += moved
+			return dropEmptyEntries(entries)
+		}
+	}
+	entries = append(entries, tracker.TimeEntry{
+		IssueKey: overflow,
+		Minutes:  moved,
+		Comment:  "Development work",
+	})
+	return dropEmptyEntries(entries)
+}
+
+// canGrow reports whether a worklog of the issue may get minutes more without
+// exceeding the estimate room
+func (m *Manager) canGrow(issueKey string, minutes float64) bool {
+	if issueKey == m.config.TimeRules.Estimates.OverflowIssue || m.fixedTaskKeys()[issueKey] {
+		return true
+	}
+	room, ok := m.estimateRoom(issueKey)
+	return !ok || room >= minutes
+}
+
+// recordPlanned adds planned entries to the time of their issues, so estimate caps of
+// later days in the same run see it before Tracker updates spent
+func (m *Manager) recordPlanned(entries []tracker.TimeEntry) {
+	if !m.config.TimeRules.Estimates.Enabled {
+		return
+	}
+	if m.plannedMinutes == nil {
+		m.plannedMinutes = make(map[string]float64)
+	}
+	for _, entry := range entries {
+		m.plannedMinutes[entry.IssueKey] += entry.Minutes
+	}
+}
+
+// rememberIssues keeps metadata of issues found by searches, estimates and spent included.
+// The first copy of an issue is kept: later searches already count worklogs this run
+// created, which plannedMinutes holds as well.
+func (m *Manager) rememberIssues(issues []tracker.Issue) {
+	if m.issueMeta == nil {
+		m.issueMeta = make(map[string]*tracker.Issue)
+	}
+	for i := range issues {
+		if _, ok := m.issueMeta[issues[i].Key]; !ok {
+			m.issueMeta[issues[i].Key] = &issues[i]
+		}
+	}
+}
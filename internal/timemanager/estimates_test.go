@@ -0,0 +1,98 @@
+package timemanager
+
+import (
+	"math"
+	"testing"
+
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/internal/tracker"
+	"go.uber.org/zap"
+)
+
+func newEstimatesManager(overflow string) *Manager {
+	cfg := &config.Config{
+		Tracker: config.TrackerConfig{BoardID: 1},
+		TimeRules: config.TimeRulesConfig{
+			Estimates: config.EstimatesConfig{Enabled: true, Factor: 1.5, OverflowIssue: overflow},
+		},
+	}
+	m := &Manager{
+		config:     cfg,
+		issueRules: NewIssueRules(cfg.TimeRules),
+		logger:     zap.NewNop(),
+	}
+	m.rememberIssues([]tracker.Issue{
+		{Key: "PROJ-1", Estimation: "PT4H", Spent: "PT5H"}, // room 1h
+		{Key: "PROJ-2", Estimation: "PT2H", Spent: "PT3H"}, // used up
+		{Key: "PROJ-3", OriginalEstimation: "P1D"},         // room 12h
+	})
+	return m
+}
+
+func TestDistributeRemaining_EstimateCaps(t *testing.T) {
+	m := newEstimatesManager("")
+
+	entries := m.distributeRemaining(480, 480, []string{"PROJ-1", "PROJ-2", "PROJ-3"}, nil)
+	got := make(map[string]float64)
+	for _, entry := range entries {
+		got[entry.IssueKey] = entry.Minutes
+	}
+
+	want := map[string]float64{"PROJ-1": 60, "PROJ-3": 420}
+	if len(got) != len(want) {
+		t.Fatalf("entries = %v, want %v", got, want)
+	}
+	for key, minutes := range want {
+		if math.Abs(got[key]-minutes) > 1e-6 {
+			t.Errorf("%s minutes = %.1f, want %.1f", key, got[key], minutes)
+		}
+	}
+
+	// Planned time counts towards the estimate on the following days
+	m.recordPlanned(entries)
+	if room, ok := m.estimateRoom("PROJ-3"); !ok || math.Abs(room-300) > 1e-6 {
+		t.Errorf("estimateRoom(PROJ-3) = %.1f, %v, want 300", room, ok)
+	}
+}
+
+func TestDistributeRemaining_OverflowIssue(t *testing.T) {
+	m := newEstimatesManager("PROJ-99")
+
+	entries := m.distributeRemaining(480, 480, []string{"PROJ-1", "PROJ-2"}, nil)
+	got := make(map[string]float64)
+	for _, entry := range entries {
+		got[entry.IssueKey] = entry.Minutes
+	}
+
+	if math.Abs(got["PROJ-1"]-60) > 1e-6 || math.Abs(got["PROJ-99"]-420) > 1e-6 || len(got) != 2 {
+		t.Errorf("entries = %v, want PROJ-1 60 and overflow PROJ-99 420", got)
+	}
+}
+
+func TestEnforceEstimates_AllCapped(t *testing.T) {
+	m := newEstimatesManager("PROJ-99")
+	m.rememberIssues([]tracker.Issue{{Key: "PROJ-4", Estimation: "PT2H", Spent: "PT1H"}}) // room 2h
+
+	// Every candidate is capped well below the 480 minute target
+	entries := []tracker.TimeEntry{{IssueKey: "PROJ-1", Minutes: 40}, {IssueKey: "PROJ-4", Minutes: 80}}
+	m.normalizeEntries(entries, 480)
+	entries = roundEntries(entries, 480, m.config.TimeRules.GetRoundingMinutes())
+	entries = m.enforceEstimates(entries)
+
+	got := make(map[string]float64)
+	total := 0.0
+	for _, entry := range entries {
+		got[entry.IssueKey] += entry.Minutes
+		total += entry.Minutes
+	}
+	if got["PROJ-1"] > 60 || got["PROJ-4"] > 120 {
+		t.Errorf("estimate caps exceeded: %v", got)
+	}
+	if math.Abs(total-480) > 1e-6 || math.Abs(got["PROJ-99"]-(480-got["PROJ-1"]-got["PROJ-4"])) > 1e-6 {
+		t.Errorf("entries = %v, want the rest of 480 minutes on overflow PROJ-99", got)
+	}
+	m.recordPlanned(entries)
+	if m.canGrow("PROJ-1", 1) {
+		t.Error("canGrow(PROJ-1) = true although its room is planned")
+	}
+}
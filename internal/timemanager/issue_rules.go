@@ -0,0 +1,125 @@
+package timemanager
+
+import (
+	"strings"
+
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/internal/tracker"
+)
+
+// issueLimits are the effective distribution limits for an issue on a given day
+type issueLimits struct {
+	Weight     float64
+	MaxMinutes float64 // 0 = unlimited
+	MinMinutes float64
+}
+
+// IssueRules resolves weights, caps and minimum entry sizes for issues
+type IssueRules struct {
+	rules           []config.IssueRuleConfig
+	defaultMinEntry float64
+}
+
+// NewIssueRules creates issue rules from time_rules config
+func NewIssueRules(cfg config.TimeRulesConfig) *IssueRules {
+	return &IssueRules{
+		rules:           cfg.IssueRules,
+		defaultMinEntry: cfg.MinEntryMinutes,
+	}
+}
+
+// NeedsMetadata reports whether any rule matches on type, tag or component
+func (r *IssueRules) NeedsMetadata() bool {
+	for i := range r.rules {
+		if r.rules[i].HasMetadataSelectors() {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns the first rule matching the issue, or nil.
+// issue may be nil when metadata was not loaded; metadata selectors then never match.
+func (r *IssueRules) Match(issueKey string, issue *tracker.Issue) *config.IssueRuleConfig {
+	for i := range r.rules {
+		if ruleMatches(&r.rules[i], issueKey, issue) {
+			return &r.rules[i]
+		}
+	}
+	return nil
+}
+
+// Limits returns effective limits for the issue for a day with the given target
+func (r *IssueRules) Limits(issueKey string, issue *tracker.Issue, targetMinutes float64) issueLimits {
+	limits := issueLimits{
+		Weight:     1,
+		MinMinutes: r.defaultMinEntry,
+	}
+
+	rule := r.Match(issueKey, issue)
+	if rule == nil {
+		return limits
+	}
+
+	if rule.Weight > 0 {
+		limits.Weight = rule.Weight
+	}
+	if rule.MinEntryMinutes > 0 {
+		limits.MinMinutes = rule.MinEntryMinutes
+	}
+	if rule.MaxMinutesPerDay > 0 {
+		limits.MaxMinutes = rule.MaxMinutesPerDay
+	}
+	if rule.MaxSharePercent > 0 {
+		shareCap := targetMinutes * rule.MaxSharePercent / 100
+		if limits.MaxMinutes == 0 || shareCap < limits.MaxMinutes {
+			limits.MaxMinutes = shareCap
+		}
+	}
+
+	return limits
+}
+
+func ruleMatches(rule *config.IssueRuleConfig, issueKey string, issue *tracker.Issue) bool {
+	if rule.Issue != "" && !strings.EqualFold(rule.Issue, issueKey) {
+		return false
+	}
+	if rule.Queue != "" && !strings.EqualFold(rule.Queue, issueQueue(issueKey)) {
+		return false
+	}
+	if !rule.HasMetadataSelectors() {
+		return true
+	}
+	if issue == nil {
+		return false
+	}
+
+	if rule.Type != "" {
+		if issue.Type == nil || !strings.EqualFold(rule.Type, issue.Type.Key) {
+			return false
+		}
+	}
+	if rule.Tag != "" && !containsFold(issue.Tags, rule.Tag) {
+		return false
+	}
+	if rule.Component != "" {
+		names := make([]string, 0, len(issue.Components))
+		for _, component := range issue.Components {
+			names = append(names, component.Display)
+		}
+		if !containsFold(names, rule.Component) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
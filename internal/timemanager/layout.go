@@ -0,0 +1,156 @@
+package timemanager
+
+import (
+	"sort"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/internal/tracker"
+	"github.com/username/time-tracker-bot/pkg/dateutil"
+)
+
+// timeSlot is a half-open interval [Start, End)
+type timeSlot struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (s timeSlot) overlaps(other timeSlot) bool {
+	return s.Start.Before(other.End) && other.Start.Before(s.End)
+}
+
+// dayLayout places worklogs on a realistic, non-overlapping timeline of a single day
+type dayLayout struct {
+	workStart time.Time
+	workEnd   time.Time
+	busy      []timeSlot
+}
+
+// newDayLayout creates a layout for the date from the workday window.
+// Lunch and meeting slots without an issue are blocked; slots bound to an issue
+// are kept free for the anchored task.
+func newDayLayout(date time.Time, cfg config.WorkdayConfig) *dayLayout {
+	layout := &dayLayout{
+		workStart: dateutil.AtMinute(date, cfg.GetStartMinute()),
+		workEnd:   dateutil.AtMinute(date, cfg.GetEndMinute()),
+	}
+
+	if lunchStart, lunchMinutes, ok := cfg.GetLunch(); ok {
+		start := dateutil.AtMinute(date, lunchStart)
+		layout.Reserve(start, start.Add(time.Duration(lunchMinutes)*time.Minute))
+	}
+
+	for _, slot := range cfg.Meetings {
+		if slot.Issue != "" {
+			continue
+		}
+		minute, err := dateutil.ParseClock(slot.At)
+		if err != nil {
+			continue
+		}
+		start := dateutil.AtMinute(date, minute)
+		layout.Reserve(start, start.Add(time.Duration(slot.Minutes)*time.Minute))
+	}
+
+	return layout
+}
+
+// Reserve marks [start, end) as occupied
+func (l *dayLayout) Reserve(start, end time.Time) {
+	if !end.After(start) {
+		return
+	}
+	l.busy = append(l.busy, timeSlot{Start: start, End: end})
+	sort.Slice(l.busy, func(i, j int) bool {
+		return l.busy[i].Start.Before(l.busy[j].Start)
+	})
+}
+
+// ReserveWorklogs blocks time already logged in Tracker
+func (l *dayLayout) ReserveWorklogs(worklogs []tracker.Worklog) {
+	for _, wl := range worklogs {
+		minutes, err := tracker.ParseISO8601Duration(wl.Duration)
+		if err != nil || minutes <= 0 {
+			continue
+		}
+		start := wl.Start.In(l.workStart.Location())
+		l.Reserve(start, start.Add(time.Duration(minutes*float64(time.Minute))))
+	}
+}
+
+// isFree reports whether the slot does not overlap anything reserved
+func (l *dayLayout) isFree(slot timeSlot) bool {
+	for _, busy := range l.busy {
+		if busy.overlaps(slot) {
+			return false
+		}
+	}
+	return true
+}
+
+// Place returns a start time for every entry. Anchored entries (non-zero Start) keep
+// their time when it is free; the rest take the earliest gap that fits, first inside
+// the workday window and after its end when the window is full.
+func (l *dayLayout) Place(entries []tracker.TimeEntry) []time.Time {
+	starts := make([]time.Time, len(entries))
+	placed := make([]bool, len(entries))
+
+	for i, entry := range entries {
+		if entry.Start.IsZero() {
+			continue
+		}
+		slot := timeSlot{Start: entry.Start, End: entry.Start.Add(entryDuration(entry))}
+		if l.isFree(slot) {
+			l.Reserve(slot.Start, slot.End)
+			starts[i] = slot.Start
+			placed[i] = true
+		}
+	}
+
+	for i, entry := range entries {
+		if placed[i] {
+			continue
+		}
+		starts[i] = l.firstFit(entryDuration(entry))
+		l.Reserve(starts[i], starts[i].Add(entryDuration(entry)))
+	}
+
+	return starts
+}
+
+// firstFit finds the earliest start from workStart where duration fits without overlap.
+// Gaps inside the window are preferred; otherwise the entry goes after the last busy slot.
+func (l *dayLayout) firstFit(duration time.Duration) time.Time {
+	cursor := l.workStart
+	for _, busy := range l.busy {
+		if !busy.End.After(cursor) {
+			continue
+		}
+		if !cursor.Add(duration).After(busy.Start) && !cursor.Add(duration).After(l.workEnd) {
+			return cursor
+		}
+		if busy.End.After(cursor) {
+			cursor = busy.End
+		}
+	}
+
+	if !cursor.Add(duration).After(l.workEnd) {
+		return cursor
+	}
+
+	// Window is full: continue right after the latest reserved slot
+	latest := l.workStart
+	for _, busy := range l.busy {
+		if busy.End.After(latest) {
+			latest = busy.End
+		}
+	}
+	if latest.Before(cursor) {
+		latest = cursor
+	}
+	return latest
+}
+
+func entryDuration(entry tracker.TimeEntry) time.Duration {
+	return time.Duration(entry.Minutes * float64(time.Minute))
+}
@@ -0,0 +1,81 @@
+package timemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/internal/tracker"
+)
+
+func clock(date time.Time, h, m int) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), h, m, 0, 0, date.Location())
+}
+
+func TestDayLayout_PlaceWithoutOverlap(t *testing.T) {
+	date := time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC)
+	cfg := config.WorkdayConfig{
+		Start:      "09:00",
+		End:        "18:00",
+		LunchStart: "13:00",
+		Meetings: []config.MeetingSlotConfig{
+			{At: "10:00", Minutes: 30, Issue: "STANDUP-1"},
+			{At: "16:00", Minutes: 60},
+		},
+	}
+
+	layout := newDayLayout(date, cfg)
+	layout.ReserveWorklogs([]tracker.Worklog{
+		{Start: tracker.TrackerTime{Time: clock(date, 9, 0)}, Duration: "PT30M"},
+	})
+
+	entries := []tracker.TimeEntry{
+		{IssueKey: "STANDUP-1", Minutes: 30, Start: clock(date, 10, 0)},
+		{IssueKey: "DEV-1", Minutes: 120},
+		{IssueKey: "DEV-2", Minutes: 30},
+		{IssueKey: "DEV-3", Minutes: 90},
+	}
+
+	starts := layout.Place(entries)
+
+	want := []time.Time{
+		clock(date, 10, 0),  // anchored to the meeting slot
+		clock(date, 10, 30), // first gap long enough, ends before lunch
+		clock(date, 9, 30),  // fills the gap before the standup
+		clock(date, 14, 0),  // after lunch, before the blocked meeting
+	}
+
+	for i := range entries {
+		if !starts[i].Equal(want[i]) {
+			t.Errorf("%s start = %s, want %s", entries[i].IssueKey, starts[i].Format("15:04"), want[i].Format("15:04"))
+		}
+	}
+
+	// No two placed entries may overlap
+	for i := range entries {
+		a := timeSlot{Start: starts[i], End: starts[i].Add(entryDuration(entries[i]))}
+		for j := i + 1; j < len(entries); j++ {
+			b := timeSlot{Start: starts[j], End: starts[j].Add(entryDuration(entries[j]))}
+			if a.overlaps(b) {
+				t.Errorf("%s overlaps %s", entries[i].IssueKey, entries[j].IssueKey)
+			}
+		}
+	}
+}
+
+func TestDayLayout_OverflowAfterWindow(t *testing.T) {
+	date := time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC)
+	layout := newDayLayout(date, config.WorkdayConfig{Start: "10:00", End: "12:00"})
+
+	starts := layout.Place([]tracker.TimeEntry{
+		{IssueKey: "A", Minutes: 90},
+		{IssueKey: "B", Minutes: 60},
+	})
+
+	if !starts[0].Equal(clock(date, 10, 0)) {
+		t.Errorf("A start = %s, want 10:00", starts[0].Format("15:04"))
+	}
+	if !starts[1].Equal(clock(date, 11, 30)) {
+		t.Errorf("B start = %s, want 11:30", starts[1].Format("15:04"))
+	}
+}
@@ -1,12 +1,15 @@
 package timemanager
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/username/time-tracker-bot/internal/calendar"
 	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/internal/store"
 	"github.com/username/time-tracker-bot/internal/tracker"
+	"github.com/username/time-tracker-bot/pkg/dateutil"
 	"github.com/username/time-tracker-bot/pkg/random"
 	"go.uber.org/zap"
 )
@@ -18,8 +21,35 @@ type Manager struct {
 	config        *config.Config
 	trackerClient *tracker.Client
 	calendar      calendar.Calendar
+	calendarDays  *calendarDays // month-granular view of calendar for range loops
 	weeklyState   *WeeklyStateManager
+	statusRules   *StatusRules
+	issueRules    *IssueRules
+	schedule      *Schedule
+	meetings      *MeetingSource  // optional: meetings from the work calendar
+	activity      ActivitySource  // optional: per-issue activity such as commits
+	ctx           context.Context // bounds calendar lookups; see SetContext
 	logger        *zap.Logger
+
+	issueMeta   map[string]*tracker.Issue // issue key → metadata for issue rules
+	issueSource map[string]string         // issue key → board or queue it came from; nil until loaded
+
+	queryResults map[string][]string // expanded issues_query → issue keys
+	queryIssues  map[string]bool     // issue keys issues_query found for any date
+
+	undatedQueryWarned bool // warned that issues_query without {date} skips backfilled days
+
+	scope            *sourceScope // boards and queues of tracker.sources; built once per run
+	userID           string       // current Tracker user, for assignee history; loaded on first use
+	userLookupFailed bool         // GetCurrentUser failed; not retried for the rest of the run
+
+	plannedMinutes map[string]float64 // issue key → minutes planned by this run, for estimate caps
+
+	unknownStatuses map[string][]string // queue → status keys missing from active_statuses
+
+	store           store.Store // optional: provenance of created worklogs
+	runID           string
+	worklogsCreated int
 }
 
 // GetTrackerClient returns the tracker client (for cleanup command)
@@ -44,11 +74,49 @@ func NewManager(
 		config:        cfg,
 		trackerClient: trackerClient,
 		calendar:      cal,
+		calendarDays:  newCalendarDays(cal),
 		weeklyState:   weeklyState,
+		statusRules:   NewStatusRules(cfg.TimeRules.ActiveStatuses),
+		issueRules:    NewIssueRules(cfg.TimeRules),
+		schedule:      NewSchedule(cfg.TimeRules),
+		issueMeta:     make(map[string]*tracker.Issue),
+		ctx:           context.Background(),
 		logger:        logger,
 	}
 }
 
+// SetContext sets the context calendar lookups run with, so an interrupted run
+// stops waiting for calendar providers
+func (m *Manager) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+// SetRun attaches the store and run ID used to record provenance of created worklogs
+func (m *Manager) SetRun(st store.Store, runID string) {
+	m.store = st
+	m.runID = runID
+}
+
+// SetMeetings attaches the work calendar whose meetings are logged with their real times
+func (m *Manager) SetMeetings(meetings *MeetingSource) {
+	m.meetings = meetings
+}
+
+// SetActivity attaches the source that weights in-progress issues by activity
+func (m *Manager) SetActivity(activity ActivitySource) {
+	m.activity = activity
+}
+
+// WorklogsCreated returns how many worklogs this manager created in Tracker
+func (m *Manager) WorklogsCreated() int {
+	return m.worklogsCreated
+}
+
+// UnknownStatuses returns status keys seen in changelogs that active_statuses does not map (queue → keys)
+func (m *Manager) UnknownStatuses() map[string][]string {
+	return m.unknownStatuses
+}
+
 // DistributeTimeForDate distributes time for the given date using historical timelines
 func (m *Manager) DistributeTimeForDate(date time.Time, dryRun bool, timelines map[string]*StatusTimeline) ([]tracker.TimeEntry, error) {
 	m.logger.Info("Starting time distribution",
@@ -65,7 +133,7 @@ func (m *Manager) DistributeTimeForDate(date time.Time, dryRun bool, timelines m
 	}
 
 	// 1. Check if it's a working day
-	isWorkday, targetHours, err := m.calendar.IsWorkday(date)
+	isWorkday, targetMinutes, err := m.dayTarget(date)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if workday: %w", err)
 	}
@@ -76,9 +144,8 @@ func (m *Manager) DistributeTimeForDate(date time.Time, dryRun bool, timelines m
 		return nil, nil
 	}
 
-	targetMinutes := float64(targetHours * 60)
 	m.logger.Info("Target working time",
-		zap.Int("hours", targetHours),
+		zap.Float64("hours", targetMinutes/60),
 		zap.Float64("minutes", targetMinutes))
 
 	// 2. Get already worked time
@@ -99,101 +166,12 @@ func (m *Manager) DistributeTimeForDate(date time.Time, dryRun bool, timelines m
 		return nil, nil
 	}
 
-	entries := []tracker.TimeEntry{}
-
-	// 3. Daily tasks
-	dailyMinutes := 0.0
-	for _, task := range m.config.TimeRules.DailyTasks {
-		minutes := random.Randomize(float64(task.Minutes), m.config.TimeRules.RandomizationPercent)
-		entries = append(entries, tracker.TimeEntry{
-			IssueKey: task.Issue,
-			Minutes:  minutes,
-			Comment:  task.Description,
-		})
-		dailyMinutes += minutes
-	}
-
-	remainingMinutes -= dailyMinutes
-	m.logger.Info("Daily tasks distributed",
-		zap.Float64("total_minutes", dailyMinutes),
-		zap.Int("count", len(m.config.TimeRules.DailyTasks)),
-		zap.Float64("remaining_minutes", remainingMinutes))
-
-	// 4. Weekly tasks
-	weeklyEntries, weeklyMinutes, err := m.distributeWeeklyTasks(date)
-	if err != nil {
-		return nil, fmt.Errorf("failed to distribute weekly tasks: %w", err)
-	}
-
-	entries = append(entries, weeklyEntries...)
-	remainingMinutes -= weeklyMinutes
-
-	m.logger.Info("Weekly tasks distributed",
-		zap.Float64("total_minutes", weeklyMinutes),
-		zap.Int("count", len(weeklyEntries)),
-		zap.Float64("remaining_minutes", remainingMinutes))
-
-	// 4.5. Board tasks (random tasks from board)
-	if m.config.TimeRules.BoardTasks.Enabled && remainingMinutes > 0 {
-		boardEntries, boardMinutes, err := m.distributeBoardTasks(date)
+	// 2.5. Personal time off logged to an absence issue replaces the regular plan
+	entries, isAbsence := m.absenceEntries(date, remainingMinutes)
+	if !isAbsence {
+		entries, err = m.planDayEntries(date, targetMinutes, remainingMinutes, timelines)
 		if err != nil {
-			return nil, fmt.Errorf("failed to distribute board tasks: %w", err)
-		}
-
-		entries = append(entries, boardEntries...)
-		remainingMinutes -= boardMinutes
-
-		m.logger.Info("Board tasks distributed",
-			zap.Float64("total_minutes", boardMinutes),
-			zap.Int("count", len(boardEntries)),
-			zap.Float64("remaining_minutes", remainingMinutes))
-	}
-
-	// 5. Distribute remaining time based on historical timelines
-	if remainingMinutes > 0 {
-		inProgressIssues := issuesInProgressOnDate(date, timelines)
-		m.logger.Info("Issues in progress from history",
-			zap.Time("date", date),
-			zap.Int("count", len(inProgressIssues)),
-			zap.Strings("issues", inProgressIssues))
-
-		if len(inProgressIssues) > 0 {
-			fixedTasks := make(map[string]bool)
-			for _, task := range m.config.TimeRules.DailyTasks {
-				fixedTasks[task.Issue] = true
-			}
-			for _, task := range m.config.TimeRules.WeeklyTasks {
-				fixedTasks[task.Issue] = true
-			}
-
-			filtered := []string{}
-			for _, issueKey := range inProgressIssues {
-				if !fixedTasks[issueKey] {
-					filtered = append(filtered, issueKey)
-				}
-			}
-
-			if len(filtered) > 0 {
-				minutesPerIssue := remainingMinutes / float64(len(filtered))
-				for _, issueKey := range filtered {
-					minutes := random.Randomize(minutesPerIssue, m.config.TimeRules.RandomizationPercent)
-					entries = append(entries, tracker.TimeEntry{
-						IssueKey: issueKey,
-						Minutes:  minutes,
-						Comment:  "Development work",
-					})
-				}
-
-				m.logger.Info("Remaining time distributed to historical in-progress issues",
-					zap.Float64("minutes_per_issue", minutesPerIssue),
-					zap.Int("issue_count", len(filtered)))
-			} else {
-				m.logger.Warn("No non-fixed in-progress issues available for distribution",
-					zap.Time("date", date))
-			}
-		} else {
-			m.logger.Warn("No in-progress issues found in history for date",
-				zap.Time("date", date))
+			return nil, err
 		}
 	}
 
@@ -204,16 +182,13 @@ func (m *Manager) DistributeTimeForDate(date time.Time, dryRun bool, timelines m
 	}
 
 	if totalMinutes > 0 && totalMinutes != targetMinutes {
-		// Normalize all entries proportionally to hit exact target
-		normalizationFactor := targetMinutes / totalMinutes
+		// Normalize all entries proportionally to hit exact target (caps respected)
 		m.logger.Info("Normalizing time entries to exact target",
 			zap.Float64("total_before", totalMinutes),
 			zap.Float64("target", targetMinutes),
-			zap.Float64("factor", normalizationFactor))
+			zap.Float64("factor", targetMinutes/totalMinutes))
 
-		for i := range entries {
-			entries[i].Minutes = entries[i].Minutes * normalizationFactor
-		}
+		m.normalizeEntries(entries, targetMinutes)
 
 		// Verify total (for logging)
 		verifyTotal := 0.0
@@ -225,6 +200,11 @@ func (m *Manager) DistributeTimeForDate(date time.Time, dryRun bool, timelines m
 			zap.Float64("target", targetMinutes))
 	}
 
+	// 7.5. Round to the configured granularity (integer total == target)
+	entries = roundEntries(entries, targetMinutes, m.config.TimeRules.GetRoundingMinutes())
+	entries = m.enforceEstimates(entries)
+	m.recordPlanned(entries)
+
 	// 8. Create worklogs (if not dry run)
 	if !dryRun {
 		if err := m.createWorklogs(date, entries); err != nil {
@@ -269,19 +249,156 @@ func (m *Manager) DistributeTimeForDate(date time.Time, dryRun bool, timelines m
 	return entries, nil
 }
 
+// absenceEntries returns a single entry logging the day to the absence issue when the
+// date is personal time off of a kind configured in time_off.absence_issue(s)
+func (m *Manager) absenceEntries(date time.Time, minutes float64) ([]tracker.TimeEntry, bool) {
+	lookup, ok := m.calendar.(calendar.TimeOffLookup)
+	if !ok {
+		return nil, false
+	}
+	timeOff, ok := lookup.TimeOffOn(date)
+	if !ok {
+		return nil, false
+	}
+	issue := m.config.TimeOff.AbsenceIssueFor(timeOff.Kind)
+	if issue == "" {
+		return nil, false
+	}
+
+	comment := timeOff.Kind
+	if timeOff.Note != "" {
+		comment = timeOff.Note
+	}
+
+	m.logger.Info("Personal time off, logging absence",
+		zap.Time("date", date),
+		zap.String("kind", timeOff.Kind),
+		zap.String("issue", issue),
+		zap.Float64("minutes", minutes))
+
+	return []tracker.TimeEntry{{
+		IssueKey: issue,
+		Minutes:  minutes,
+		Comment:  comment,
+	}}, true
+}
+
+// planDayEntries plans daily, weekly, board and in-progress entries for the remaining minutes
+func (m *Manager) planDayEntries(date time.Time, targetMinutes, remainingMinutes float64, timelines map[string]*StatusTimeline) ([]tracker.TimeEntry, error) {
+	entries := []tracker.TimeEntry{}
+
+	// 2.8. Meetings from the work calendar keep their real start and duration
+	meetingEntries, meetingMinutes, meetingIssues, err := m.meetingEntries(date, remainingMinutes)
+	if err != nil {
+		return nil, err
+	}
+	entries = append(entries, meetingEntries...)
+
+	remainingMinutes -= meetingMinutes
+	if len(meetingEntries) > 0 {
+		m.logger.Info("Meetings logged",
+			zap.Float64("total_minutes", meetingMinutes),
+			zap.Int("count", len(meetingEntries)),
+			zap.Float64("remaining_minutes", remainingMinutes))
+	}
+
+	// 3. Daily tasks
+	dailyEntries, dailyMinutes := m.dailyTaskEntries(date, meetingIssues)
+	entries = append(entries, dailyEntries...)
+
+	remainingMinutes -= dailyMinutes
+	m.logger.Info("Daily tasks distributed",
+		zap.Float64("total_minutes", dailyMinutes),
+		zap.Int("count", len(dailyEntries)),
+		zap.Float64("remaining_minutes", remainingMinutes))
+
+	// 4. Weekly tasks
+	weeklyEntries, weeklyMinutes, err := m.distributeWeeklyTasks(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to distribute weekly tasks: %w", err)
+	}
+
+	entries = append(entries, weeklyEntries...)
+	remainingMinutes -= weeklyMinutes
+
+	m.logger.Info("Weekly tasks distributed",
+		zap.Float64("total_minutes", weeklyMinutes),
+		zap.Int("count", len(weeklyEntries)),
+		zap.Float64("remaining_minutes", remainingMinutes))
+
+	// 4.5. Board tasks (random tasks from board)
+	if m.config.TimeRules.BoardTasks.Enabled && remainingMinutes > 0 {
+		boardEntries, boardMinutes, err := m.distributeBoardTasks(date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to distribute board tasks: %w", err)
+		}
+
+		entries = append(entries, boardEntries...)
+		remainingMinutes -= boardMinutes
+
+		m.logger.Info("Board tasks distributed",
+			zap.Float64("total_minutes", boardMinutes),
+			zap.Int("count", len(boardEntries)),
+			zap.Float64("remaining_minutes", remainingMinutes))
+	}
+
+	// 5. Distribute remaining time based on historical timelines and issues_query
+	if remainingMinutes > 0 {
+		monthStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+		inProgressIssues := m.dayCandidates(date, monthStart, date, timelines)
+		m.logger.Info("Issues in progress from history",
+			zap.Time("date", date),
+			zap.Int("count", len(inProgressIssues)),
+			zap.Strings("issues", inProgressIssues))
+
+		if len(inProgressIssues) > 0 {
+			fixedTasks := m.fixedTaskKeys()
+
+			filtered := []string{}
+			for _, issueKey := range inProgressIssues {
+				if !fixedTasks[issueKey] {
+					filtered = append(filtered, issueKey)
+				}
+			}
+
+			if len(filtered) > 0 {
+				devEntries := m.distributeRemaining(remainingMinutes, targetMinutes, filtered, m.activityOn(date))
+				entries = append(entries, devEntries...)
+
+				m.logger.Info("Remaining time distributed to historical in-progress issues",
+					zap.Float64("remaining_minutes", remainingMinutes),
+					zap.Int("issue_count", len(filtered)),
+					zap.Int("entry_count", len(devEntries)))
+			} else {
+				m.logger.Warn("No non-fixed in-progress issues available for distribution",
+					zap.Time("date", date))
+			}
+		} else {
+			m.logger.Warn("No in-progress issues found in history for date",
+				zap.Time("date", date))
+		}
+	}
+
+	return entries, nil
+}
+
 // distributeWeeklyTasks distributes weekly tasks for the given date
 func (m *Manager) distributeWeeklyTasks(date time.Time) ([]tracker.TimeEntry, float64, error) {
 	// Check if we need to select new days for the week
 	if m.weeklyState.IsNewWeek(date) {
-		m.logger.Info("New week detected, selecting random days")
+		m.logger.Info("No weekly selection for this week, selecting random days",
+			zap.String("week", weekKey(date)))
 
-		// Build map of task -> days per week
-		weeklyTasks := make(map[string]int)
-		for _, task := range m.config.TimeRules.WeeklyTasks {
-			weeklyTasks[task.Issue] = task.DaysPerWeek
+		workdays, err := m.remainingWorkdaysOfWeek(date)
+		if err != nil {
+			return nil, 0, err
+		}
+		carriedWeeks, err := m.holidayWeeksBefore(date)
+		if err != nil {
+			return nil, 0, err
 		}
 
-		if err := m.weeklyState.SelectDaysForWeek(date, weeklyTasks); err != nil {
+		if err := m.weeklyState.SelectDaysForWeek(date, m.config.TimeRules.WeeklyTasks, workdays, carriedWeeks); err != nil {
 			return nil, 0, fmt.Errorf("failed to select days for week: %w", err)
 		}
 	}
@@ -292,9 +409,11 @@ func (m *Manager) distributeWeeklyTasks(date time.Time) ([]tracker.TimeEntry, fl
 	// Check if today is a selected day for each task
 	for _, task := range m.config.TimeRules.WeeklyTasks {
 		if m.weeklyState.IsSelectedDay(date, task.Issue) {
-			// Calculate hours per day
-			hoursPerDay := task.HoursPerWeek / float64(task.DaysPerWeek)
-			minutesPerDay := hoursPerDay * 60
+			minutesPerDay, ok := m.weeklyState.GetTaskMinutes(date, task.Issue)
+			if !ok {
+				// Week saved by an older version: split evenly over days_per_week
+				minutesPerDay = task.HoursPerWeek / float64(task.DaysPerWeek) * 60
+			}
 
 			minutes := random.Randomize(minutesPerDay, m.config.TimeRules.RandomizationPercent)
 
@@ -315,19 +434,83 @@ func (m *Manager) distributeWeeklyTasks(date time.Time) ([]tracker.TimeEntry, fl
 	return entries, totalMinutes, nil
 }
 
-// excludeFixedTasks excludes daily and weekly tasks from the issue list
-func (m *Manager) excludeFixedTasks(issues []tracker.Issue) []tracker.Issue {
-	fixedTasks := make(map[string]bool)
+// maxCarriedWeeks bounds how many weeks without workdays are carried into the next one
+const maxCarriedWeeks = 4
+
+// holidayWeeksBefore returns the weeks right before the week of date that had no
+// workdays at all (e.g. New Year holidays). Weekly tasks are only scheduled from
+// workdays, so such weeks never get a selection and their hours would be lost.
+// Weeks off because of personal time off (a vacation) are not carried over.
+func (m *Manager) holidayWeeksBefore(date time.Time) ([]string, error) {
+	weeks := []string{}
+	monday := dateutil.StartOfWeek(date)
+	for i := 1; i <= maxCarriedWeeks; i++ {
+		previous := monday.AddDate(0, 0, -7*i)
+		if !m.weeklyState.IsNewWeek(previous) {
+			break
+		}
+		workdays, err := m.remainingWorkdaysOfWeek(previous)
+		if err != nil {
+			return nil, err
+		}
+		if len(workdays) > 0 || m.hasTimeOffInWeek(previous) {
+			break
+		}
+		weeks = append(weeks, weekKey(previous))
+	}
+	return weeks, nil
+}
 
-	// Add daily tasks
-	for _, task := range m.config.TimeRules.DailyTasks {
-		fixedTasks[task.Issue] = true
+// hasTimeOffInWeek reports whether personal time off covers a Monday..Friday of the week
+func (m *Manager) hasTimeOffInWeek(date time.Time) bool {
+	lookup, ok := m.calendar.(calendar.TimeOffLookup)
+	if !ok {
+		return false
+	}
+	monday := dateutil.StartOfWeek(date)
+	for day := monday; day.Before(monday.AddDate(0, 0, 5)); day = day.AddDate(0, 0, 1) {
+		if _, ok := lookup.TimeOffOn(day); ok {
+			return true
+		}
 	}
+	return false
+}
 
-	// Add weekly tasks
-	for _, task := range m.config.TimeRules.WeeklyTasks {
-		fixedTasks[task.Issue] = true
+// dayTarget returns whether the date is a personal workday and its target in minutes.
+// It is the single source of daily targets: calendar minutes adjusted by time_rules.schedule.
+func (m *Manager) dayTarget(date time.Time) (bool, float64, error) {
+	isWorkday, minutes, err := m.calendarDays.Workday(m.ctx, date)
+	if err != nil {
+		return false, 0, err
+	}
+	if !isWorkday {
+		return false, 0, nil
+	}
+
+	target := m.schedule.TargetMinutes(date, float64(minutes))
+	return target > 0, target, nil
+}
+
+// remainingWorkdaysOfWeek returns calendar workdays from date to the end of its week,
+// including transferred working Saturdays
+func (m *Manager) remainingWorkdaysOfWeek(date time.Time) ([]time.Time, error) {
+	workdays := []time.Time{}
+	sunday := dateutil.EndOfWeek(date)
+	for day := dateutil.StartOfDay(date); day.Before(sunday); day = day.AddDate(0, 0, 1) {
+		isWorkday, _, err := m.dayTarget(day)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check workday %s: %w", day.Format("2006-01-02"), err)
+		}
+		if isWorkday {
+			workdays = append(workdays, day)
+		}
 	}
+	return workdays, nil
+}
+
+// excludeFixedTasks excludes daily and weekly tasks from the issue list
+func (m *Manager) excludeFixedTasks(issues []tracker.Issue) []tracker.Issue {
+	fixedTasks := m.fixedTaskKeys()
 
 	// Filter out fixed tasks
 	filtered := []tracker.Issue{}
@@ -340,39 +523,227 @@ func (m *Manager) excludeFixedTasks(issues []tracker.Issue) []tracker.Issue {
 	return filtered
 }
 
-// createWorklogs creates worklog entries in Tracker
+// dailyTaskEntries builds entries for daily tasks scheduled on the date. Tasks with
+// at, or bound to a workday meeting slot, are anchored to that time. Tasks of
+// skipIssues (logged from real meetings that day) are left out.
+func (m *Manager) dailyTaskEntries(date time.Time, skipIssues map[string]bool) ([]tracker.TimeEntry, float64) {
+	slots := make(map[string]string)
+	for _, slot := range m.config.TimeRules.Workday.Meetings {
+		if slot.Issue != "" {
+			slots[slot.Issue] = slot.At
+		}
+	}
+
+	entries := []tracker.TimeEntry{}
+	total := 0.0
+	for _, task := range m.config.TimeRules.DailyTasks {
+		if !dailyTaskAppliesOn(task, date) || skipIssues[task.Issue] {
+			continue
+		}
+		minutes := random.Randomize(float64(task.Minutes), m.config.TimeRules.RandomizationPercent)
+		entries = append(entries, tracker.TimeEntry{
+			IssueKey: task.Issue,
+			Minutes:  minutes,
+			Comment:  task.Description,
+			Start:    dailyTaskStart(task, date, slots),
+		})
+		total += minutes
+	}
+
+	return entries, total
+}
+
+// fixedTaskKeys returns issues that get fixed time from daily and weekly rules
+func (m *Manager) fixedTaskKeys() map[string]bool {
+	fixedTasks := make(map[string]bool)
+	for _, task := range m.config.TimeRules.DailyTasks {
+		fixedTasks[task.Issue] = true
+	}
+	for _, task := range m.config.TimeRules.WeeklyTasks {
+		fixedTasks[task.Issue] = true
+	}
+	return fixedTasks
+}
+
+// distributeRemaining splits remaining minutes across issues using weights, caps and
+// minimum entry sizes from time_rules.issue_rules, shifted towards issues with activity.
+// Weights and shares of tracker.sources apply to issues by their source.
+func (m *Manager) distributeRemaining(remainingMinutes, targetMinutes float64, issueKeys []string, activity map[string]float64) []tracker.TimeEntry {
+	sourceWeights, sourceShares := m.sourceSettings()
+
+	items := make([]allocationItem, 0, len(issueKeys))
+	sources := make([]string, 0, len(issueKeys))
+	for _, issueKey := range issueKeys {
+		limits := m.issueRules.Limits(issueKey, m.issueMeta[issueKey], targetMinutes)
+		if room, ok := m.estimateRoom(issueKey); ok && room < 1 {
+			m.logger.Info("Issue estimate is used up, skipped",
+				zap.String("issue", issueKey),
+				zap.Float64("estimate_minutes", m.issueMeta[issueKey].EstimateMinutes()),
+				zap.Float64("spent_minutes", m.issueMeta[issueKey].SpentMinutes()+m.plannedMinutes[issueKey]))
+			continue
+		}
+		items = append(items, allocationItem{
+			IssueKey:   issueKey,
+			Weight:     random.Randomize(limits.Weight*sourceWeight(sourceWeights, m.sourceOf(issueKey)), m.config.TimeRules.RandomizationPercent),
+			MaxMinutes: m.capByEstimate(issueKey, limits.MaxMinutes),
+			MinMinutes: limits.MinMinutes,
+		})
+		sources = append(sources, m.sourceOf(issueKey))
+	}
+	weightByActivity(items, activity, m.config.Activity.GetShare())
+
+	// Sources with a share get a fixed part of the minutes, the rest is split by weight
+	allocation := make([]float64, len(items))
+	leftover := 0.0
+	for _, group := range splitBySourceShare(remainingMinutes, sources, sourceShares) {
+		groupItems := make([]allocationItem, len(group.indices))
+		for j, idx := range group.indices {
+			groupItems[j] = items[idx]
+		}
+		groupAllocation, groupLeftover := allocateWithMinimums(group.minutes, groupItems)
+		for j, idx := range group.indices {
+			allocation[idx] = groupAllocation[j]
+		}
+		leftover += groupLeftover
+	}
+	entries := make([]tracker.TimeEntry, 0, len(issueKeys)+1)
+	for i, minutes := range allocation {
+		if minutes <= 0 {
+			m.logger.Debug("Entry merged into larger ones",
+				zap.String("issue", items[i].IssueKey))
+			continue
+		}
+		entries = append(entries, tracker.TimeEntry{
+			IssueKey: items[i].IssueKey,
+			Minutes:  minutes,
+			Comment:  "Development work",
+			Source:   sources[i],
+		})
+	}
+
+	if leftover > 0 {
+		overflow := m.config.TimeRules.Estimates.OverflowIssue
+		if overflow == "" {
+			m.logger.Warn("Issue caps left part of the day unallocated",
+				zap.Float64("leftover_minutes", leftover),
+				zap.Int("issue_count", len(issueKeys)))
+			return entries
+		}
+		m.logger.Info("Issue caps left part of the day unallocated, logging it to the overflow issue",
+			zap.Float64("leftover_minutes", leftover),
+			zap.String("issue", overflow))
+		entries = append(entries, tracker.TimeEntry{
+			IssueKey: overflow,
+			Minutes:  leftover,
+			Comment:  "Development work",
+		})
+	}
+
+	return entries
+}
+
+// normalizeEntries scales entries to exactly targetMinutes without exceeding per-issue caps
+func (m *Manager) normalizeEntries(entries []tracker.TimeEntry, targetMinutes float64) {
+	caps := make(map[string]float64)
+	for _, entry := range entries {
+		limits := m.issueRules.Limits(entry.IssueKey, m.issueMeta[entry.IssueKey], targetMinutes)
+		if maxMinutes := m.capByEstimate(entry.IssueKey, limits.MaxMinutes); maxMinutes > 0 {
+			caps[entry.IssueKey] = maxMinutes
+		}
+	}
+
+	if !scaleEntriesToTarget(entries, targetMinutes, caps) {
+		m.logger.Warn("Issue caps cannot absorb the daily target, caps exceeded to reach it",
+			zap.Float64("target_minutes", targetMinutes))
+	}
+}
+
+// requireFreshCalendar refuses changes to worklogs of a date whose calendar month
+// came from an expired cache: its target may be wrong and worklogs are hard to undo
+func (m *Manager) requireFreshCalendar(date time.Time) error {
+	stale, err := m.calendarDays.Stale(m.ctx, date)
+	if err != nil {
+		return fmt.Errorf("failed to check calendar: %w", err)
+	}
+	if stale {
+		return fmt.Errorf("%w for %s: no calendar source is reachable, worklogs are not changed",
+			calendar.ErrStaleData, date.Format("2006-01"))
+	}
+	return nil
+}
+
+// createWorklogs creates worklog entries in Tracker laid out on a non-overlapping day timeline
 func (m *Manager) createWorklogs(date time.Time, entries []tracker.TimeEntry) error {
-	startTime := time.Date(date.Year(), date.Month(), date.Day(), 10, 0, 0, 0, date.Location())
+	if err := m.requireFreshCalendar(date); err != nil {
+		return err
+	}
+
+	layout := newDayLayout(date, m.config.TimeRules.Workday)
+
+	// Keep clear of everything already logged for the day
+	existing, err := m.trackerClient.GetWorklogsForToday(date)
+	if err != nil {
+		return fmt.Errorf("failed to get existing worklogs: %w", err)
+	}
+	layout.ReserveWorklogs(existing)
+
+	starts := layout.Place(entries)
 
 	for i, entry := range entries {
-		// Calculate start time (stagger entries)
-		entryStart := startTime.Add(time.Duration(i*5) * time.Minute)
+		entryStart := starts[i]
 
 		// Format duration
 		durationISO := tracker.FormatDuration(entry.Minutes)
 
 		// Create worklog
-		_, err := m.trackerClient.CreateWorklog(entry.IssueKey, entryStart, durationISO, entry.Comment)
+		worklog, err := m.trackerClient.CreateWorklog(entry.IssueKey, entryStart, durationISO, entry.Comment)
 		if err != nil {
 			m.logger.Error("Failed to create worklog",
 				zap.String("issue", entry.IssueKey),
 				zap.Error(err))
 			return fmt.Errorf("failed to create worklog for %s: %w", entry.IssueKey, err)
 		}
+		m.worklogsCreated++
+		m.recordWorklog(date, entry, entryStart, worklog)
 
 		m.logger.Info("Worklog created",
 			zap.String("issue", entry.IssueKey),
 			zap.Float64("minutes", entry.Minutes),
-			zap.String("duration", durationISO))
+			zap.String("duration", durationISO),
+			zap.Time("start", entryStart))
 	}
 
 	return nil
 }
 
+// recordWorklog stores provenance of a created worklog; failures only log a warning
+func (m *Manager) recordWorklog(date time.Time, entry tracker.TimeEntry, start time.Time, worklog *tracker.Worklog) {
+	if m.store == nil || worklog == nil {
+		return
+	}
+
+	record := &store.WorklogRecord{
+		RunID:     m.runID,
+		WorklogID: worklog.ID.String(),
+		IssueKey:  entry.IssueKey,
+		Date:      date.Format("2006-01-02"),
+		Start:     start,
+		Minutes:   entry.Minutes,
+		Comment:   entry.Comment,
+		CreatedAt: time.Now(),
+	}
+	if err := m.store.AddWorklog(record); err != nil {
+		m.logger.Warn("Failed to record worklog provenance",
+			zap.String("issue", entry.IssueKey),
+			zap.String("worklog_id", record.WorklogID),
+			zap.Error(err))
+	}
+}
+
 // GetStatus returns current status for the date
 func (m *Manager) GetStatus(date time.Time) (float64, float64, error) {
 	// Check if workday
-	isWorkday, targetHours, err := m.calendar.IsWorkday(date)
+	isWorkday, targetMinutes, err := m.dayTarget(date)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -387,8 +758,6 @@ func (m *Manager) GetStatus(date time.Time) (float64, float64, error) {
 		return 0, 0, err
 	}
 
-	targetMinutes := float64(targetHours * 60)
-
 	return workedMinutes, targetMinutes, nil
 }
 
@@ -437,6 +806,7 @@ type DailyStatus struct {
 	Date          time.Time
 	TargetMinutes float64
 	WorkedMinutes float64
+	BySource      map[string]float64 // worked minutes by board or queue; set with several sources
 }
 
 // BackfillPeriod fills missing time entries for a period using 120% coverage algorithm.
@@ -490,7 +860,7 @@ func (m *Manager) BackfillPeriod(from, to time.Time, dryRun bool, timelines map[
 	}
 
 	for _, day := range missingDays {
-		dayResult, err := m.backfillDay(day, timelines, dryRun)
+		dayResult, err := m.backfillDay(day, from, to, timelines, dryRun)
 		if err != nil {
 			m.logger.Error("Failed to backfill day",
 				zap.Time("date", day),
@@ -530,29 +900,29 @@ func (m *Manager) collectAllRelevantIssues(from, to time.Time) ([]string, error)
 		zap.Int("count", len(worklogKeys)),
 		zap.Strings("keys", worklogKeys))
 
-	// Source 2: Current board (tasks on board now)
-	boardIssues, err := m.trackerClient.GetAllBoardIssues(m.config.Tracker.BoardID)
+	// Source 2: Configured boards and queues (tasks there now)
+	sourceIssues, err := m.loadSourceIssues()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get board issues: %w", err)
+		return nil, err
 	}
 	boardKeys := []string{}
-	for _, issue := range boardIssues {
+	for _, issue := range sourceIssues {
 		boardKeys = append(boardKeys, issue.Key)
 	}
-	m.logger.Info("Source 2: Current board",
+	m.logger.Info("Source 2: Boards and queues",
 		zap.Int("count", len(boardKeys)),
 		zap.Strings("keys", boardKeys))
 
 	// Merge sources
 	allKeys := mergeUnique(worklogKeys, boardKeys)
-	m.logger.Info("Merged sources (worklogs + board)",
+	m.logger.Info("Merged sources (worklogs + boards)",
 		zap.Int("total_unique", len(allKeys)))
 
 	return allKeys, nil
 }
 
-// backfillDay performs backfill for a single day
-func (m *Manager) backfillDay(date time.Time, timelines map[string]*StatusTimeline, dryRun bool) (*DayBackfillResult, error) {
+// backfillDay performs backfill for a single day of the from..to period
+func (m *Manager) backfillDay(date, from, to time.Time, timelines map[string]*StatusTimeline, dryRun bool) (*DayBackfillResult, error) {
 	m.logger.Info("Backfilling day",
 		zap.Time("date", date))
 
@@ -562,13 +932,11 @@ func (m *Manager) backfillDay(date time.Time, timelines map[string]*StatusTimeli
 		return nil, fmt.Errorf("failed to check worked time: %w", err)
 	}
 
-	_, targetHours, err := m.calendar.IsWorkday(date)
+	_, targetMinutes, err := m.dayTarget(date)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check workday: %w", err)
 	}
 
-	targetMinutes := float64(targetHours * 60)
-
 	if workedMinutes >= targetMinutes {
 		m.logger.Info("Day already has sufficient time logged, skipping",
 			zap.Time("date", date),
@@ -580,8 +948,29 @@ func (m *Manager) backfillDay(date time.Time, timelines map[string]*StatusTimeli
 		}, nil
 	}
 
-	// Find tasks that were "inProgress" on this day
-	inProgressIssues := issuesInProgressOnDate(date, timelines)
+	// Personal time off logged to an absence issue replaces the regular plan
+	if absence, ok := m.absenceEntries(date, targetMinutes-workedMinutes); ok {
+		absence = roundEntries(absence, targetMinutes-workedMinutes, m.config.TimeRules.GetRoundingMinutes())
+		if !dryRun {
+			if err := m.createWorklogs(date, absence); err != nil {
+				return nil, fmt.Errorf("failed to create worklogs: %w", err)
+			}
+		}
+		totalMinutes := 0.0
+		for _, entry := range absence {
+			totalMinutes += entry.Minutes
+		}
+		return &DayBackfillResult{
+			Date:         date,
+			Success:      true,
+			EntriesCount: len(absence),
+			TotalMinutes: totalMinutes,
+			Entries:      absence,
+		}, nil
+	}
+
+	// Find tasks that were "inProgress" on this day or match issues_query for it
+	inProgressIssues := m.dayCandidates(date, from, to, timelines)
 
 	m.logger.Info("Tasks in progress on date",
 		zap.Time("date", date),
@@ -600,20 +989,19 @@ func (m *Manager) backfillDay(date time.Time, timelines map[string]*StatusTimeli
 	// targetMinutes already calculated above during idempotency check
 	entries := []tracker.TimeEntry{}
 
-	// Distribute time: daily tasks + weekly tasks + inProgress tasks
-	// 1. Daily tasks
-	dailyMinutes := 0.0
-	for _, task := range m.config.TimeRules.DailyTasks {
-		minutes := random.Randomize(float64(task.Minutes), m.config.TimeRules.RandomizationPercent)
-		entries = append(entries, tracker.TimeEntry{
-			IssueKey: task.Issue,
-			Minutes:  minutes,
-			Comment:  task.Description,
-		})
-		dailyMinutes += minutes
+	// Distribute time: meetings + daily tasks + weekly tasks + inProgress tasks
+	// 0. Meetings with their real start and duration
+	meetingEntries, meetingMinutes, meetingIssues, err := m.meetingEntries(date, targetMinutes-workedMinutes)
+	if err != nil {
+		return nil, err
 	}
+	entries = append(entries, meetingEntries...)
+
+	// 1. Daily tasks
+	dailyEntries, dailyMinutes := m.dailyTaskEntries(date, meetingIssues)
+	entries = append(entries, dailyEntries...)
 
-	remainingMinutes := targetMinutes - dailyMinutes
+	remainingMinutes := targetMinutes - meetingMinutes - dailyMinutes
 
 	// 2. Weekly tasks (check if selected for this day)
 	weeklyEntries, weeklyMinutes, err := m.distributeWeeklyTasks(date)
@@ -626,13 +1014,7 @@ func (m *Manager) backfillDay(date time.Time, timelines map[string]*StatusTimeli
 	// 3. Distribute remaining to inProgress tasks
 	if remainingMinutes > 0 && len(inProgressIssues) > 0 {
 		// Exclude fixed tasks from inProgress list
-		fixedTasks := make(map[string]bool)
-		for _, task := range m.config.TimeRules.DailyTasks {
-			fixedTasks[task.Issue] = true
-		}
-		for _, task := range m.config.TimeRules.WeeklyTasks {
-			fixedTasks[task.Issue] = true
-		}
+		fixedTasks := m.fixedTaskKeys()
 
 		filteredInProgress := []string{}
 		for _, key := range inProgressIssues {
@@ -642,16 +1024,7 @@ func (m *Manager) backfillDay(date time.Time, timelines map[string]*StatusTimeli
 		}
 
 		if len(filteredInProgress) > 0 {
-			minutesPerIssue := remainingMinutes / float64(len(filteredInProgress))
-
-			for _, issueKey := range filteredInProgress {
-				minutes := random.Randomize(minutesPerIssue, m.config.TimeRules.RandomizationPercent)
-				entries = append(entries, tracker.TimeEntry{
-					IssueKey: issueKey,
-					Minutes:  minutes,
-					Comment:  "Development work",
-				})
-			}
+			entries = append(entries, m.distributeRemaining(remainingMinutes, targetMinutes, filteredInProgress, m.activityOn(date))...)
 		}
 	}
 
@@ -662,11 +1035,16 @@ func (m *Manager) backfillDay(date time.Time, timelines map[string]*StatusTimeli
 	}
 
 	if totalMinutes > 0 && totalMinutes != targetMinutes {
-		normalizationFactor := targetMinutes / totalMinutes
-		for i := range entries {
-			entries[i].Minutes = entries[i].Minutes * normalizationFactor
-		}
-		totalMinutes = targetMinutes
+		m.normalizeEntries(entries, targetMinutes)
+	}
+
+	// Round to the configured granularity before anything is sent
+	entries = roundEntries(entries, targetMinutes, m.config.TimeRules.GetRoundingMinutes())
+	entries = m.enforceEstimates(entries)
+	m.recordPlanned(entries)
+	totalMinutes = 0
+	for _, entry := range entries {
+		totalMinutes += entry.Minutes
 	}
 
 	// Create worklogs (if not dry run)
@@ -698,16 +1076,18 @@ func (m *Manager) GetMonthlyStatus(from, to time.Time) (*MonthlyStatus, error) {
 	}
 
 	// Calculate target minutes based on calendar (handles shortened days)
+	dailyTarget := make(map[string]float64)
 	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
-		isWorkday, hours, err := m.calendar.IsWorkday(d)
+		isWorkday, targetMinutes, err := m.dayTarget(d)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check workday for %s: %w", d.Format("2006-01-02"), err)
 		}
-		if !isWorkday || hours == 0 {
+		if !isWorkday {
 			continue
 		}
+		dailyTarget[d.Format("2006-01-02")] = targetMinutes
 		status.WorkingDays++
-		status.TargetMinutes += float64(hours * 60)
+		status.TargetMinutes += targetMinutes
 	}
 
 	// Sum worked minutes from Tracker worklogs
@@ -716,8 +1096,18 @@ func (m *Manager) GetMonthlyStatus(from, to time.Time) (*MonthlyStatus, error) {
 		return nil, fmt.Errorf("failed to get worklogs for range: %w", err)
 	}
 
+	// Time by source is reported only when there is more than one to tell apart
+	bySource := len(m.config.Tracker.GetSources()) > 1
+	if bySource && m.issueSource == nil {
+		if _, err := m.loadSourceIssues(); err != nil {
+			m.logger.Warn("Failed to load source issues, time by source is not reported", zap.Error(err))
+			bySource = false
+		}
+	}
+
 	// Aggregate worked minutes per day
 	dailyWorked := make(map[string]float64)
+	dailyBySource := make(map[string]map[string]float64)
 	for _, wl := range worklogs {
 		minutes, parseErr := tracker.ParseISO8601Duration(wl.Duration)
 		if parseErr != nil {
@@ -729,19 +1119,18 @@ func (m *Manager) GetMonthlyStatus(from, to time.Time) (*MonthlyStatus, error) {
 		}
 		dayKey := wl.Start.In(time.Local).Format("2006-01-02")
 		dailyWorked[dayKey] += minutes
+		if bySource {
+			if dailyBySource[dayKey] == nil {
+				dailyBySource[dayKey] = make(map[string]float64)
+			}
+			dailyBySource[dayKey][m.sourceLabel(wl.Issue.Key)] += minutes
+		}
 	}
 
 	// Build daily breakdown in order
 	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
 		dayKey := d.Format("2006-01-02")
-		targetMinutes := 0.0
-		isWorkday, hours, err := m.calendar.IsWorkday(d)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check workday for %s: %w", dayKey, err)
-		}
-		if isWorkday {
-			targetMinutes = float64(hours * 60)
-		}
+		targetMinutes := dailyTarget[dayKey]
 		worked := dailyWorked[dayKey]
 		if worked > 0 {
 			status.WorkedMinutes += worked
@@ -751,6 +1140,7 @@ func (m *Manager) GetMonthlyStatus(from, to time.Time) (*MonthlyStatus, error) {
 				Date:          d,
 				TargetMinutes: targetMinutes,
 				WorkedMinutes: worked,
+				BySource:      dailyBySource[dayKey],
 			})
 		}
 	}
@@ -763,12 +1153,15 @@ func (m *Manager) GetMonthlyStatus(from, to time.Time) (*MonthlyStatus, error) {
 func (m *Manager) cleanupAndNormalize(date time.Time) error {
 	m.logger.Info("Starting cleanup and normalization", zap.Time("date", date))
 
+	if err := m.requireFreshCalendar(date); err != nil {
+		return err
+	}
+
 	// 1. Get target
-	_, targetHours, err := m.calendar.IsWorkday(date)
+	_, targetMinutes, err := m.dayTarget(date)
 	if err != nil {
 		return fmt.Errorf("failed to check workday: %w", err)
 	}
-	targetMinutes := float64(targetHours * 60)
 
 	// 2. Get all worklogs
 	worklogs, err := m.trackerClient.GetWorklogsForToday(date)
@@ -929,28 +1322,30 @@ func (m *Manager) cleanupAndNormalize(date time.Time) error {
 			zap.Float64("target", targetMinutes),
 			zap.Float64("diff", diff))
 
-		// Find largest worklog to adjust
-		largestIdx := 0
+		// Find largest worklog to adjust; issues at their estimate cap are not extended
+		largestIdx := -1
 		largestMinutes := 0.0
 		for i, wl := range toKeep {
-			m, _ := tracker.ParseISO8601Duration(wl.Duration)
-			if m > largestMinutes {
-				largestMinutes = m
+			minutes, _ := tracker.ParseISO8601Duration(wl.Duration)
+			if diff > 0 && !m.canGrow(wl.Issue.Key, diff) {
+				continue
+			}
+			if minutes > largestMinutes {
+				largestMinutes = minutes
 				largestIdx = i
 			}
 		}
 
-		largest := toKeep[largestIdx]
-		newMinutes := largestMinutes + diff
-
-		if newMinutes > 0 {
+		if largestIdx < 0 {
+			m.logger.Warn("No worklog can be extended without exceeding an estimate, day left short",
+				zap.Float64("missing_minutes", diff))
+		} else if newMinutes := largestMinutes + diff; newMinutes > 0 {
+			largest := toKeep[largestIdx]
 			// Delete and recreate with adjusted duration
 			worklogID := largest.ID.String()
 			if err := m.trackerClient.DeleteWorklog(largest.Issue.Key, worklogID); err == nil {
 				// Create with exact duration
-				hours := int(newMinutes / 60)
-				mins := int(newMinutes) % 60
-				duration := fmt.Sprintf("PT%dH%dM", hours, mins)
+				duration := tracker.FormatDuration(newMinutes)
 
 				if _, err := m.trackerClient.CreateWorklog(largest.Issue.Key, largest.Start.Time, duration, largest.Comment); err == nil {
 					m.logger.Info("Adjusted worklog to reach exact target",
@@ -970,7 +1365,7 @@ func (m *Manager) cleanupAndNormalize(date time.Time) error {
 	return nil
 }
 
-// distributeBoardTasks distributes random time across random tasks from board
+// distributeBoardTasks distributes random time across random tasks from the boards and queues
 func (m *Manager) distributeBoardTasks(date time.Time) ([]tracker.TimeEntry, float64, error) {
 	cfg := m.config.TimeRules.BoardTasks
 
@@ -982,8 +1377,8 @@ func (m *Manager) distributeBoardTasks(date time.Time) ([]tracker.TimeEntry, flo
 		return nil, 0, nil
 	}
 
-	// Get all issues from board (regardless of status)
-	allIssues, err := m.trackerClient.GetAllBoardIssues(m.config.Tracker.BoardID)
+	// Get all issues from boards and queues (regardless of status)
+	allIssues, err := m.loadSourceIssues()
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get board issues: %w", err)
 	}
@@ -993,8 +1388,15 @@ func (m *Manager) distributeBoardTasks(date time.Time) ([]tracker.TimeEntry, flo
 		return nil, 0, nil
 	}
 
-	// Exclude fixed tasks (daily + weekly)
+	// Exclude fixed tasks (daily + weekly) and issues whose estimate is used up
 	allIssues = m.excludeFixedTasks(allIssues)
+	withRoom := allIssues[:0]
+	for _, issue := range allIssues {
+		if room, ok := m.estimateRoom(issue.Key); !ok || room >= 1 {
+			withRoom = append(withRoom, issue)
+		}
+	}
+	allIssues = withRoom
 
 	if len(allIssues) == 0 {
 		m.logger.Warn("All board issues are fixed tasks, skipping board_tasks")
@@ -1037,6 +1439,7 @@ func (m *Manager) distributeBoardTasks(date time.Time) ([]tracker.TimeEntry, flo
 			IssueKey: issue.Key,
 			Minutes:  minutes,
 			Comment:  fmt.Sprintf("Board task (auto-distributed on %s)", date.Format("2006-01-02")),
+			Source:   m.issueSource[issue.Key],
 		})
 
 		actualTotal += minutes
@@ -0,0 +1,213 @@
+package timemanager
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/internal/tracker"
+	"github.com/username/time-tracker-bot/pkg/ics"
+	"go.uber.org/zap"
+)
+
+type meetingRule struct {
+	issue    string
+	title    *regexp.Regexp // nil = any title
+	attendee string         // "" = any attendees
+}
+
+// Meeting is a calendar meeting mapped to an issue
+type Meeting struct {
+	IssueKey string
+	Title    string
+	Start    time.Time
+	Minutes  float64
+}
+
+// MeetingSource reads meetings from an exported work calendar and maps them to
+// issues by meetings.rules, then by an issue key in the description
+type MeetingSource struct {
+	path   string
+	rules  []meetingRule
+	queues map[string]bool
+	events []ics.Event
+	logger *zap.Logger
+}
+
+// NewMeetingSource creates a meeting source from validated config; call Load before use
+func NewMeetingSource(cfg config.MeetingsConfig, logger *zap.Logger) (*MeetingSource, error) {
+	source := &MeetingSource{path: cfg.File, logger: logger}
+
+	for i, ruleCfg := range cfg.Rules {
+		rule := meetingRule{issue: ruleCfg.Issue, attendee: strings.ToLower(ruleCfg.Attendee)}
+		if ruleCfg.Title != "" {
+			title, err := regexp.Compile(ruleCfg.Title)
+			if err != nil {
+				return nil, fmt.Errorf("meetings.rules[%d].title: %w", i, err)
+			}
+			rule.title = title
+		}
+		source.rules = append(source.rules, rule)
+	}
+
+	if len(cfg.Queues) > 0 {
+		source.queues = make(map[string]bool, len(cfg.Queues))
+		for _, queue := range cfg.Queues {
+			source.queues[strings.ToUpper(queue)] = true
+		}
+	}
+
+	return source, nil
+}
+
+// Load reads the calendar export
+func (s *MeetingSource) Load() error {
+	events, err := ics.ParseFile(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to load meetings: %w", err)
+	}
+	s.events = events
+
+	s.logger.Info("Meetings calendar loaded",
+		zap.String("file", s.path),
+		zap.Int("events", len(events)))
+
+	return nil
+}
+
+// MeetingsOn returns the mapped meetings starting on the date, in start order.
+// All-day, cancelled and unmapped events are skipped.
+func (s *MeetingSource) MeetingsOn(date time.Time) ([]Meeting, error) {
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	events, err := ics.Expand(s.events, dayStart, dayStart.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand meetings: %w", err)
+	}
+
+	var meetings []Meeting
+	for i := range events {
+		event := &events[i]
+		start := event.Start.In(date.Location())
+		if event.AllDay || event.Status == "CANCELLED" || !sameDate(start, dayStart) {
+			continue
+		}
+
+		minutes := math.Round(event.End.Sub(event.Start).Minutes())
+		if minutes <= 0 {
+			continue
+		}
+
+		issue := s.issueFor(event)
+		if issue == "" {
+			s.logger.Debug("Meeting not mapped to an issue",
+				zap.String("title", event.Summary),
+				zap.Time("start", start))
+			continue
+		}
+
+		meetings = append(meetings, Meeting{
+			IssueKey: issue,
+			Title:    event.Summary,
+			Start:    start,
+			Minutes:  minutes,
+		})
+	}
+
+	return meetings, nil
+}
+
+// issueFor maps an event to an issue: the first matching rule, then the first
+// issue key of an allowed queue in the description
+func (s *MeetingSource) issueFor(event *ics.Event) string {
+	for _, rule := range s.rules {
+		if rule.title != nil && !rule.title.MatchString(event.Summary) {
+			continue
+		}
+		if rule.attendee != "" && !hasAttendee(event, rule.attendee) {
+			continue
+		}
+		return rule.issue
+	}
+
+	for _, key := range tracker.FindIssueKeys(event.Description) {
+		if s.queues == nil || s.queues[tracker.QueueOf(key)] {
+			return key
+		}
+	}
+	return ""
+}
+
+func hasAttendee(event *ics.Event, email string) bool {
+	if event.Organizer == email {
+		return true
+	}
+	for _, attendee := range event.Attendees {
+		if attendee == email {
+			return true
+		}
+	}
+	return false
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
+// meetingEntries returns fixed entries for meetings on the date that fit into the
+// remaining minutes and are not logged yet, and the issues they cover
+func (m *Manager) meetingEntries(date time.Time, remainingMinutes float64) ([]tracker.TimeEntry, float64, map[string]bool, error) {
+	covered := make(map[string]bool)
+	if m.meetings == nil {
+		return nil, 0, covered, nil
+	}
+
+	meetings, err := m.meetings.MeetingsOn(date)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if len(meetings) == 0 {
+		return nil, 0, covered, nil
+	}
+
+	// A meeting already logged by a previous run is not logged again
+	existing, err := m.trackerClient.GetWorklogsForToday(date)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to get existing worklogs: %w", err)
+	}
+	logged := make(map[string]bool)
+	for _, wl := range existing {
+		logged[wl.Issue.Key+"@"+wl.Start.In(date.Location()).Format("15:04")] = true
+	}
+
+	var entries []tracker.TimeEntry
+	total := 0.0
+	for _, meeting := range meetings {
+		covered[meeting.IssueKey] = true
+		if logged[meeting.IssueKey+"@"+meeting.Start.Format("15:04")] {
+			continue
+		}
+		if total+meeting.Minutes > remainingMinutes {
+			m.logger.Warn("Meeting does not fit into the rest of the day, skipped",
+				zap.String("issue", meeting.IssueKey),
+				zap.String("title", meeting.Title),
+				zap.Time("start", meeting.Start),
+				zap.Float64("minutes", meeting.Minutes),
+				zap.Float64("remaining_minutes", remainingMinutes-total))
+			continue
+		}
+
+		entries = append(entries, tracker.TimeEntry{
+			IssueKey: meeting.IssueKey,
+			Minutes:  meeting.Minutes,
+			Comment:  meeting.Title,
+			Start:    meeting.Start,
+			Fixed:    true,
+		})
+		total += meeting.Minutes
+	}
+
+	return entries, total, covered, nil
+}
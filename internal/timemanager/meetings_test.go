@@ -0,0 +1,105 @@
+package timemanager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/config"
+	"go.uber.org/zap"
+)
+
+const workCalendar = `BEGIN:VCALENDAR
+BEGIN:VEVENT
+UID:standup
+DTSTART:20251103T070000Z
+DTEND:20251103T071500Z
+RRULE:FREQ=DAILY
+SUMMARY:Daily standup
+END:VEVENT
+BEGIN:VEVENT
+UID:arch
+DTSTART:20251103T090000Z
+DTEND:20251103T100000Z
+SUMMARY:Architecture review
+ORGANIZER:mailto:architect@example.com
+END:VEVENT
+BEGIN:VEVENT
+UID:design
+DTSTART:20251103T110000Z
+DTEND:20251103T113000Z
+SUMMARY:Design sync
+DESCRIPTION:See UTF-8 notes for PROJ-42
+END:VEVENT
+BEGIN:VEVENT
+UID:cancelled
+DTSTART:20251103T120000Z
+DTEND:20251103T130000Z
+SUMMARY:Daily standup extra
+STATUS:CANCELLED
+END:VEVENT
+BEGIN:VEVENT
+UID:lunch
+DTSTART:20251103T130000Z
+DTEND:20251103T140000Z
+SUMMARY:Lunch
+END:VEVENT
+BEGIN:VEVENT
+UID:offsite
+DTSTART;VALUE=DATE:20251103
+SUMMARY:Offsite
+DESCRIPTION:PROJ-7
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestMeetingSource_MeetingsOn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "work.ics")
+	if err := os.WriteFile(path, []byte(workCalendar), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := NewMeetingSource(config.MeetingsConfig{
+		File: path,
+		Rules: []config.MeetingRuleConfig{
+			{Issue: "PROJ-101", Title: "(?i)standup"},
+			{Issue: "PROJ-200", Attendee: "Architect@example.com"},
+		},
+		Queues: []string{"proj"},
+	}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := source.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	meetings, err := source.MeetingsOn(time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("MeetingsOn() error = %v", err)
+	}
+
+	want := []Meeting{
+		{IssueKey: "PROJ-101", Title: "Daily standup", Start: time.Date(2025, 11, 3, 7, 0, 0, 0, time.UTC), Minutes: 15},
+		{IssueKey: "PROJ-200", Title: "Architecture review", Start: time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC), Minutes: 60},
+		{IssueKey: "PROJ-42", Title: "Design sync", Start: time.Date(2025, 11, 3, 11, 0, 0, 0, time.UTC), Minutes: 30},
+	}
+	if len(meetings) != len(want) {
+		t.Fatalf("MeetingsOn() = %+v, want %d meetings", meetings, len(want))
+	}
+	for i := range want {
+		if meetings[i] != want[i] {
+			t.Errorf("meeting %d = %+v, want %+v", i, meetings[i], want[i])
+		}
+	}
+
+	// The recurring standup is the only meeting the next day
+	meetings, err = source.MeetingsOn(time.Date(2025, 11, 4, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meetings) != 1 || meetings[0].IssueKey != "PROJ-101" {
+		t.Errorf("MeetingsOn(Nov 4) = %+v, want the standup", meetings)
+	}
+}
@@ -0,0 +1,124 @@
+package timemanager
+
+import (
+	"strings"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/tracker"
+	"go.uber.org/zap"
+)
+
+// sourceScope lists the boards and queues of tracker.sources
+type sourceScope struct {
+	boards     []int
+	boardNames map[string]bool // names of board sources
+	queues     map[string]bool
+}
+
+func (m *Manager) sourceScope() sourceScope {
+	scope := sourceScope{boardNames: make(map[string]bool), queues: make(map[string]bool)}
+	for _, src := range m.config.Tracker.GetSources() {
+		if src.Queue != "" {
+			scope.queues[strings.ToUpper(src.Queue)] = true
+			continue
+		}
+		scope.boards = append(scope.boards, src.Board)
+		scope.boardNames[src.GetName()] = true
+	}
+	return scope
+}
+
+// runScope returns the source scope of this run, built on first use
+func (m *Manager) runScope() sourceScope {
+	if m.scope == nil {
+		scope := m.sourceScope()
+		m.scope = &scope
+	}
+	return *m.scope
+}
+
+// inQueue reports whether the issue key belongs to one of the queues
+func (s sourceScope) inQueue(issueKey string) bool {
+	queue, _, found := strings.Cut(issueKey, "-")
+	return found && s.queues[strings.ToUpper(queue)]
+}
+
+// wasEverInScope reports whether the issue was ever on a configured board or queue:
+// it is there now, its key is in a queue, or the changelog shows it on a board
+func (m *Manager) wasEverInScope(scope sourceScope, issueKey string, changelog []tracker.ChangelogEntry) bool {
+	if m.issueSource[issueKey] != "" || scope.inQueue(issueKey) {
+		return true
+	}
+	for _, boardID := range scope.boards {
+		if wasOnBoard(changelog, boardID) {
+			return true
+		}
+	}
+	return false
+}
+
+// inScopeOnDate reports whether the issue was on a configured board, or is in a
+// configured queue, on the date. Without board changes in the changelog the issue
+// has always been where it is now.
+func (m *Manager) inScopeOnDate(scope sourceScope, timeline *StatusTimeline, date time.Time) bool {
+	if scope.inQueue(timeline.IssueKey) {
+		return true
+	}
+
+	boards, ok := timeline.BoardsOnDate(date)
+	if !ok {
+		return scope.boardNames[m.issueSource[timeline.IssueKey]]
+	}
+	for _, boardID := range scope.boards {
+		if checkBoardInValue(boards, boardID) {
+			return true
+		}
+	}
+	return false
+}
+
+// currentUserID returns the ID of the Tracker user, empty when it cannot be loaded.
+// A failed lookup is remembered and not retried.
+func (m *Manager) currentUserID() string {
+	if m.userID != "" || m.userLookupFailed || m.trackerClient == nil {
+		return m.userID
+	}
+	user, err := m.trackerClient.GetCurrentUser()
+	if err != nil {
+		m.userLookupFailed = true
+		m.logger.Warn("Failed to get current user, assignee history is not checked", zap.Error(err))
+		return ""
+	}
+	m.userID = string(user.ID)
+	return m.userID
+}
+
+// filterHistory keeps issues in progress by their history only on days they were on
+// a configured board or queue and were not assigned to someone else
+func (m *Manager) filterHistory(date time.Time, issueKeys []string, timelines map[string]*StatusTimeline) []string {
+	scope := m.runScope()
+	userID := m.currentUserID()
+
+	filtered := make([]string, 0, len(issueKeys))
+	for _, issueKey := range issueKeys {
+		timeline := timelines[issueKey]
+		if timeline == nil {
+			continue
+		}
+		if !m.inScopeOnDate(scope, timeline, date) {
+			m.logger.Debug("Issue was not on a configured board or queue on date, skipped",
+				zap.String("issue", issueKey),
+				zap.Time("date", date))
+			continue
+		}
+		if assignee, ok := timeline.AssigneeOnDate(date); ok && userID != "" && assignee != "" && assignee != userID {
+			m.logger.Debug("Issue was assigned to someone else on date, skipped",
+				zap.String("issue", issueKey),
+				zap.String("assignee", assignee),
+				zap.Time("date", date))
+			continue
+		}
+		filtered = append(filtered, issueKey)
+	}
+	return filtered
+}
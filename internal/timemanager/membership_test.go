@@ -0,0 +1,87 @@
+package timemanager
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/internal/tracker"
+	"go.uber.org/zap"
+)
+
+func changelogEntry(at time.Time, field string, from, to interface{}) tracker.ChangelogEntry {
+	return tracker.ChangelogEntry{
+		UpdatedAt: tracker.TrackerTime{Time: at},
+		Fields:    []tracker.FieldChange{{Field: tracker.FieldInfo{ID: field}, From: from, To: to}},
+	}
+}
+
+func board(id float64) []interface{} {
+	return []interface{}{map[string]interface{}{"id": id}}
+}
+
+func user(id string) map[string]interface{} {
+	return map[string]interface{}{"id": id, "display": id}
+}
+
+func TestFilterHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 11, d, 10, 0, 0, 0, time.Local) }
+	inProgress := changelogEntry(day(1), "status", nil, map[string]interface{}{"key": "inProgress"})
+
+	timelines := map[string]*StatusTimeline{
+		// Moved from another team's board 7 to ours on Nov 10
+		"PROJ-1": buildStatusTimeline("PROJ-1", []tracker.ChangelogEntry{
+			inProgress,
+			changelogEntry(day(10), "boards", board(7), board(1)),
+		}),
+		// Ours, handed over to a colleague on Nov 12
+		"PROJ-2": buildStatusTimeline("PROJ-2", []tracker.ChangelogEntry{
+			inProgress,
+			changelogEntry(day(12), "assignee", user("me"), user("colleague")),
+		}),
+		// On our board now without board changes
+		"PROJ-3": buildStatusTimeline("PROJ-3", []tracker.ChangelogEntry{inProgress}),
+		// Logged to once, never on our board
+		"OPS-4": buildStatusTimeline("OPS-4", []tracker.ChangelogEntry{inProgress}),
+		// In the support queue
+		"SUP-5": buildStatusTimeline("SUP-5", []tracker.ChangelogEntry{inProgress}),
+	}
+
+	m := &Manager{
+		config: &config.Config{Tracker: config.TrackerConfig{Sources: []config.TrackerSourceConfig{
+			{Board: 1},
+			{Queue: "SUP"},
+		}}},
+		logger:      zap.NewNop(),
+		userID:      "me",
+		issueSource: map[string]string{"PROJ-1": "board:1", "PROJ-2": "board:1", "PROJ-3": "board:1", "SUP-5": "queue:SUP"},
+	}
+	keys := []string{"OPS-4", "PROJ-1", "PROJ-2", "PROJ-3", "SUP-5"}
+
+	if got, want := m.filterHistory(day(5), keys, timelines), []string{"PROJ-2", "PROJ-3", "SUP-5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filterHistory(Nov 5) = %v, want %v", got, want)
+	}
+	if got, want := m.filterHistory(day(12), keys, timelines), []string{"PROJ-1", "PROJ-3", "SUP-5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filterHistory(Nov 12) = %v, want %v", got, want)
+	}
+	if m.scope == nil {
+		t.Error("source scope is not kept for the run")
+	}
+}
+
+func TestWasEverInScope(t *testing.T) {
+	m := &Manager{config: &config.Config{Tracker: config.TrackerConfig{BoardID: 1}}}
+	scope := m.sourceScope()
+	at := time.Date(2025, 11, 3, 10, 0, 0, 0, time.Local)
+
+	left := []tracker.ChangelogEntry{changelogEntry(at, "boards", board(1), nil)}
+	if !m.wasEverInScope(scope, "PROJ-1", left) {
+		t.Error("issue that left the board is out of scope")
+	}
+
+	other := []tracker.ChangelogEntry{changelogEntry(at, "boards", nil, board(7))}
+	if m.wasEverInScope(scope, "OPS-1", other) {
+		t.Error("issue only on another board is in scope")
+	}
+}
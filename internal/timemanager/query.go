@@ -0,0 +1,201 @@
+package timemanager
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/tracker"
+	"github.com/username/time-tracker-bot/pkg/dateutil"
+	"go.uber.org/zap"
+)
+
+// querySource labels issues found by tracker.issues_query
+const querySource = "issues_query"
+
+// expandIssuesQuery substitutes {date}, {from} and {to} in the query with YYYY-MM-DD dates
+func expandIssuesQuery(query string, date, from, to time.Time) string {
+	return strings.NewReplacer(
+		"{date}", date.Format("2006-01-02"),
+		"{from}", from.Format("2006-01-02"),
+		"{to}", to.Format("2006-01-02"),
+	).Replace(query)
+}
+
+// isDatedQuery reports whether the query uses {date}, {from} or {to}
+func isDatedQuery(query string) bool {
+	return strings.Contains(query, "{date}") || strings.Contains(query, "{from}") || strings.Contains(query, "{to}")
+}
+
+// queryCandidates returns issues tracker.issues_query finds for the date of the from..to
+// range. Results are kept per expanded query, so a query without {date} runs once.
+// A query without template variables describes the present (e.g. Status: inProgress),
+// so it applies to the current date only and is skipped for backfilled days.
+func (m *Manager) queryCandidates(date, from, to time.Time) ([]string, string, error) {
+	if m.config.Tracker.IssuesQuery == "" {
+		return nil, "", nil
+	}
+	if !isDatedQuery(m.config.Tracker.IssuesQuery) && !dateutil.IsSameDay(date, dateutil.Today()) {
+		if !m.undatedQueryWarned {
+			m.undatedQueryWarned = true
+			m.logger.Warn("issues_query has no {date}, {from} or {to}, used for today only; backfilled days use issue history",
+				zap.String("query", m.config.Tracker.IssuesQuery))
+		}
+		return nil, m.config.Tracker.IssuesQuery, nil
+	}
+	query := expandIssuesQuery(m.config.Tracker.IssuesQuery, date, from, to)
+	if keys, ok := m.queryResults[query]; ok {
+		return keys, query, nil
+	}
+
+	issues, err := m.trackerClient.SearchIssues(query)
+	if err != nil {
+		return nil, query, fmt.Errorf("failed to run issues_query: %w", err)
+	}
+	m.rememberIssues(issues)
+
+	if m.queryResults == nil {
+		m.queryResults = make(map[string][]string)
+		m.queryIssues = make(map[string]bool)
+	}
+	keys := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+		m.queryIssues[issue.Key] = true
+	}
+	m.queryResults[query] = keys
+	return keys, query, nil
+}
+
+// dayCandidates returns issues time of the date may go to: issues in progress that
+// day by their history, while on a configured board or queue and not assigned to
+// someone else, plus what tracker.issues_query finds for the date. A failing query
+// only logs a warning, so history alone still fills the day.
+func (m *Manager) dayCandidates(date, from, to time.Time, timelines map[string]*StatusTimeline) []string {
+	inProgress := m.filterHistory(date, issuesInProgressOnDate(date, timelines, m.statusRules), timelines)
+
+	queryKeys, query, err := m.queryCandidates(date, from, to)
+	if err != nil {
+		m.logger.Warn("issues_query failed, using issue history only",
+			zap.Time("date", date),
+			zap.String("query", query),
+			zap.Error(err))
+	}
+	if len(queryKeys) > 0 {
+		m.logger.Info("Issues found by issues_query",
+			zap.Time("date", date),
+			zap.Int("count", len(queryKeys)),
+			zap.Strings("issues", queryKeys))
+	}
+
+	return mergeUnique(inProgress, queryKeys)
+}
+
+// SourcePreview lists the issues one candidate source returned
+type SourcePreview struct {
+	Name   string
+	Query  string // Tracker query the source ran, empty for worklogs and history
+	Issues []string
+	Err    error
+}
+
+// CandidatePreview shows where the candidates of a date come from
+type CandidatePreview struct {
+	Date       time.Time
+	From       time.Time // start of the history range (month start)
+	Sources    []SourcePreview
+	Candidates []string // issues the remaining time of the date is distributed to
+}
+
+// PreviewCandidates runs every candidate source for the date without logging time.
+// A failing source is reported in its preview and does not stop the others.
+func (m *Manager) PreviewCandidates(date time.Time) *CandidatePreview {
+	from := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	preview := &CandidatePreview{Date: date, From: from}
+
+	worklogs := SourcePreview{Name: "worklogs"}
+	if found, err := m.trackerClient.GetWorklogsForRange(from, date); err != nil {
+		worklogs.Err = err
+	} else {
+		worklogs.Issues = extractUniqueIssueKeys(found)
+		sort.Strings(worklogs.Issues)
+	}
+	preview.Sources = append(preview.Sources, worklogs)
+
+	// Sources are loaded the way sync loads them; state they fill is dropped afterwards
+	defer m.restoreState(m.issueMeta, m.issueSource, m.queryResults, m.queryIssues)
+	m.issueMeta = copyIssueMeta(m.issueMeta)
+	m.queryResults, m.queryIssues = nil, nil
+
+	sourceIssues, err := m.loadSourceIssues()
+	failed := sourceErrors(err)
+	for _, src := range m.config.Tracker.GetSources() {
+		name := src.GetName()
+		source := SourcePreview{Name: name, Query: tracker.QueueQuery(src.Queue), Err: failed[name]}
+		if src.Queue == "" {
+			source.Query = tracker.BoardQuery(src.Board)
+		}
+		for _, issue := range sourceIssues {
+			if m.issueSource[issue.Key] == name {
+				source.Issues = append(source.Issues, issue.Key)
+			}
+		}
+		preview.Sources = append(preview.Sources, source)
+	}
+	sourceKeys := make([]string, 0, len(sourceIssues))
+	for _, issue := range sourceIssues {
+		sourceKeys = append(sourceKeys, issue.Key)
+	}
+	historyKeys := mergeUnique(worklogs.Issues, sourceKeys)
+
+	queryKeys, query, err := m.queryCandidates(date, from, date)
+	preview.Sources = append(preview.Sources, SourcePreview{Name: querySource, Query: query, Issues: queryKeys, Err: err})
+
+	// History: issues in progress on the date by their changelogs
+	timelines := m.loadTimelines(historyKeys)
+	inProgress := m.filterHistory(date, issuesInProgressOnDate(date, timelines, m.statusRules), timelines)
+	preview.Sources = append(preview.Sources, SourcePreview{Name: "in progress", Issues: inProgress})
+
+	fixedTasks := m.fixedTaskKeys()
+	for _, key := range mergeUnique(inProgress, queryKeys) {
+		if !fixedTasks[key] {
+			preview.Candidates = append(preview.Candidates, key)
+		}
+	}
+
+	return preview
+}
+
+// restoreState puts back issue state PreviewCandidates replaced
+func (m *Manager) restoreState(issueMeta map[string]*tracker.Issue, issueSource map[string]string, queryResults map[string][]string, queryIssues map[string]bool) {
+	m.issueMeta = issueMeta
+	m.issueSource = issueSource
+	m.queryResults = queryResults
+	m.queryIssues = queryIssues
+}
+
+func copyIssueMeta(issueMeta map[string]*tracker.Issue) map[string]*tracker.Issue {
+	copied := make(map[string]*tracker.Issue, len(issueMeta))
+	for key, issue := range issueMeta {
+		copied[key] = issue
+	}
+	return copied
+}
+
+// sourceErrors returns failures of loadSourceIssues by source name
+func sourceErrors(err error) map[string]error {
+	failed := make(map[string]error)
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return failed
+	}
+	for _, e := range joined.Unwrap() {
+		var srcErr *sourceError
+		if errors.As(e, &srcErr) {
+			failed[srcErr.source] = srcErr.err
+		}
+	}
+	return failed
+}
@@ -0,0 +1,73 @@
+package timemanager
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/pkg/dateutil"
+	"go.uber.org/zap"
+)
+
+func TestExpandIssuesQuery(t *testing.T) {
+	date := time.Date(2025, 11, 12, 0, 0, 0, 0, time.Local)
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2025, 11, 14, 0, 0, 0, 0, time.Local)
+
+	got := expandIssuesQuery(`Assignee: me() AND (Resolved: {date} OR Updated: {from}..{to})`, date, from, to)
+	want := `Assignee: me() AND (Resolved: 2025-11-12 OR Updated: 2025-11-01..2025-11-14)`
+	if got != want {
+		t.Errorf("expandIssuesQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestDayCandidates_MergesQueryResults(t *testing.T) {
+	date := time.Date(2025, 11, 12, 0, 0, 0, 0, time.Local)
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.Local)
+
+	m := &Manager{
+		config: &config.Config{Tracker: config.TrackerConfig{
+			IssuesQuery: "Assignee: me() AND Resolved: {date}",
+		}},
+		statusRules: NewStatusRules(config.StatusRulesConfig{}),
+		logger:      zap.NewNop(),
+		// Already searched: no Tracker request is made
+		queryResults: map[string][]string{
+			"Assignee: me() AND Resolved: 2025-11-12": {"PROJ-7", "PROJ-1"},
+		},
+		queryIssues: map[string]bool{"PROJ-7": true, "PROJ-1": true},
+		issueSource: map[string]string{"PROJ-1": "board:1"},
+	}
+
+	got := m.dayCandidates(date, from, date, nil)
+	if want := []string{"PROJ-1", "PROJ-7"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dayCandidates() = %v, want %v", got, want)
+	}
+
+	// Board issues keep their board, the rest is reported under issues_query
+	if m.sourceLabel("PROJ-1") != "board:1" || m.sourceLabel("PROJ-7") != querySource || m.sourceLabel("PROJ-9") != otherSource {
+		t.Errorf("source labels = %s / %s / %s", m.sourceLabel("PROJ-1"), m.sourceLabel("PROJ-7"), m.sourceLabel("PROJ-9"))
+	}
+}
+
+func TestQueryCandidates_UndatedQueryTodayOnly(t *testing.T) {
+	query := `Status: "inProgress" AND Assignee: me()`
+	m := &Manager{
+		config: &config.Config{Tracker: config.TrackerConfig{IssuesQuery: query}},
+		logger: zap.NewNop(),
+		// Already searched: no Tracker request is made
+		queryResults: map[string][]string{query: {"PROJ-1"}},
+		queryIssues:  map[string]bool{"PROJ-1": true},
+	}
+
+	today := dateutil.Today()
+	if keys, _, err := m.queryCandidates(today, today, today); err != nil || !reflect.DeepEqual(keys, []string{"PROJ-1"}) {
+		t.Errorf("queryCandidates(today) = %v, %v, want [PROJ-1]", keys, err)
+	}
+
+	past := today.AddDate(0, -3, 0)
+	if keys, _, err := m.queryCandidates(past, past, past); err != nil || len(keys) != 0 {
+		t.Errorf("queryCandidates(3 months ago) = %v, %v, want none", keys, err)
+	}
+}
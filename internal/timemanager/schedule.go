@@ -0,0 +1,113 @@
+package timemanager
+
+import (
+	"sort"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/pkg/dateutil"
+)
+
+// schedulePeriod is a parsed time_rules.schedule entry
+type schedulePeriod struct {
+	from  time.Time
+	fte   float64
+	hours map[time.Weekday]float64 // nil = use fte
+}
+
+// Schedule turns calendar working time into a personal daily target
+// (part-time FTE or custom hours per weekday, changing on effective dates)
+type Schedule struct {
+	periods         []schedulePeriod // sorted by from
+	standardMinutes float64          // full calendar day, from target_hours_per_day
+}
+
+// NewSchedule creates a schedule from time_rules config; invalid entries are skipped
+// (config validation reports them)
+func NewSchedule(cfg config.TimeRulesConfig) *Schedule {
+	s := &Schedule{
+		standardMinutes: float64(cfg.GetTargetMinutesPerDay()),
+	}
+
+	for _, entry := range cfg.Schedule {
+		from, err := time.ParseInLocation("2006-01-02", entry.EffectiveFrom, time.Local)
+		if err != nil {
+			continue
+		}
+		period := schedulePeriod{from: from, fte: entry.FTE}
+		if len(entry.Hours) > 0 {
+			period.hours = make(map[time.Weekday]float64, len(entry.Hours))
+			for name, hours := range entry.Hours {
+				if day, err := dateutil.ParseWeekday(name); err == nil {
+					period.hours[day] = hours
+				}
+			}
+		}
+		s.periods = append(s.periods, period)
+	}
+
+	sort.Slice(s.periods, func(i, j int) bool {
+		return s.periods[i].from.Before(s.periods[j].from)
+	})
+
+	return s
+}
+
+// averageHours returns the average hours of the period's working weekdays
+func (p *schedulePeriod) averageHours() float64 {
+	total, days := 0.0, 0
+	for _, hours := range p.hours {
+		if hours > 0 {
+			total += hours
+			days++
+		}
+	}
+	if days == 0 {
+		return 0
+	}
+	return total / float64(days)
+}
+
+// periodAt returns the period in effect on the date, or nil before the first one
+func (s *Schedule) periodAt(date time.Time) *schedulePeriod {
+	day := date.Format("2006-01-02")
+	var current *schedulePeriod
+	for i := range s.periods {
+		if s.periods[i].from.Format("2006-01-02") > day {
+			break
+		}
+		current = &s.periods[i]
+	}
+	return current
+}
+
+// TargetMinutes returns the personal target for a calendar workday of calendarMinutes.
+// Shortened calendar days scale custom weekday hours proportionally.
+func (s *Schedule) TargetMinutes(date time.Time, calendarMinutes float64) float64 {
+	if calendarMinutes <= 0 {
+		return 0
+	}
+
+	period := s.periodAt(date)
+	if period == nil {
+		return calendarMinutes
+	}
+
+	if period.hours != nil {
+		hours, ok := period.hours[date.Weekday()]
+		if !ok && dateutil.IsWeekend(date) {
+			// A working Saturday of the calendar replaces a weekday: an average working day
+			hours = period.averageHours()
+		}
+		if s.standardMinutes <= 0 {
+			return hours * 60
+		}
+		return hours * 60 * calendarMinutes / s.standardMinutes
+	}
+
+	if period.fte > 0 {
+		return calendarMinutes * period.fte
+	}
+
+	return calendarMinutes
+}
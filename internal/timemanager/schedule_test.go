@@ -0,0 +1,91 @@
+package timemanager
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/config"
+)
+
+func TestScheduleTargetMinutes(t *testing.T) {
+	schedule := NewSchedule(config.TimeRulesConfig{
+		TargetHoursPerDay: 8,
+		Schedule: []config.ScheduleConfig{
+			{EffectiveFrom: "2025-12-15", Hours: map[string]float64{
+				"mon": 8, "tue": 8, "wed": 8, "thu": 8, "fri": 0,
+			}},
+			{EffectiveFrom: "2025-12-01", FTE: 0.8},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		calendar float64
+		want     float64
+	}{
+		{"before first period", time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC), 480, 480},
+		{"fte", time.Date(2025, 12, 2, 0, 0, 0, 0, time.UTC), 480, 384},
+		{"fte on shortened day", time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC), 420, 336},
+		{"custom hours after switch", time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC), 480, 480},
+		{"day off in custom hours", time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC), 480, 0},
+		{"custom hours on shortened day", time.Date(2025, 12, 30, 0, 0, 0, 0, time.UTC), 420, 420},
+		{"calendar day off", time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC), 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := schedule.TargetMinutes(tt.date, tt.calendar)
+			if math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("TargetMinutes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleTargetMinutes_ScalesCustomHours(t *testing.T) {
+	schedule := NewSchedule(config.TimeRulesConfig{
+		Schedule: []config.ScheduleConfig{
+			{EffectiveFrom: "2025-01-01", Hours: map[string]float64{"wed": 4}},
+		},
+	})
+
+	// Shortened 7h calendar day: 4h * 7/8
+	got := schedule.TargetMinutes(time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), 420)
+	if math.Abs(got-210) > 1e-6 {
+		t.Errorf("TargetMinutes() = %v, want 210", got)
+	}
+}
+
+func TestScheduleTargetMinutes_WorkingSaturday(t *testing.T) {
+	schedule := NewSchedule(config.TimeRulesConfig{
+		TargetHoursPerDay: 8,
+		Schedule: []config.ScheduleConfig{
+			{EffectiveFrom: "2025-01-01", Hours: map[string]float64{
+				"mon": 6, "tue": 6, "wed": 6, "thu": 6, "fri": 0,
+			}},
+		},
+	})
+
+	// Saturday Nov 1, 2025 is a shortened working day in the calendar without weekday
+	// hours: the average 6h working day, scaled by 7/8
+	got := schedule.TargetMinutes(time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), 420)
+	if math.Abs(got-315) > 1e-6 {
+		t.Errorf("TargetMinutes(working Saturday) = %v, want 315", got)
+	}
+}
+
+func TestScheduleTargetMinutes_FourDayWeek(t *testing.T) {
+	schedule := NewSchedule(config.TimeRulesConfig{
+		TargetHoursPerDay: 8,
+		Schedule: []config.ScheduleConfig{
+			{EffectiveFrom: "2025-01-01", Hours: map[string]float64{"mon": 8, "tue": 8, "wed": 8, "thu": 8}},
+		},
+	})
+
+	// Friday is missing from hours: a day off
+	if got := schedule.TargetMinutes(time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC), 480); got != 0 {
+		t.Errorf("TargetMinutes(Friday) = %v, want 0", got)
+	}
+}
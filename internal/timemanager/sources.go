@@ -0,0 +1,161 @@
+package timemanager
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/internal/tracker"
+	"go.uber.org/zap"
+)
+
+// otherSource labels time on issues that come from no configured source
+const otherSource = "other"
+
+// sourceError is the failure of one configured board or queue
+type sourceError struct {
+	source string
+	err    error
+}
+
+func (e *sourceError) Error() string {
+	return fmt.Sprintf("failed to get issues of %s: %v", e.source, e.err)
+}
+
+func (e *sourceError) Unwrap() error {
+	return e.err
+}
+
+// loadSourceIssues loads issues of every configured board and queue and records the
+// source of each issue. An issue found in several sources belongs to the first one.
+// A failing source does not stop the others; the failures are returned joined.
+func (m *Manager) loadSourceIssues() ([]tracker.Issue, error) {
+	all := []tracker.Issue{}
+	issueSource := make(map[string]string)
+	var errs []error
+
+	for _, src := range m.config.Tracker.GetSources() {
+		name := src.GetName()
+		issues, err := m.fetchSource(src)
+		if err != nil {
+			errs = append(errs, &sourceError{source: name, err: err})
+			continue
+		}
+		m.logger.Info("Source issues",
+			zap.String("source", name),
+			zap.Int("count", len(issues)))
+		m.rememberIssues(issues)
+
+		for _, issue := range issues {
+			if _, ok := issueSource[issue.Key]; ok {
+				continue
+			}
+			issueSource[issue.Key] = name
+			all = append(all, issue)
+		}
+	}
+
+	m.issueSource = issueSource
+	return all, errors.Join(errs...)
+}
+
+func (m *Manager) fetchSource(src config.TrackerSourceConfig) ([]tracker.Issue, error) {
+	if src.Queue != "" {
+		return m.trackerClient.GetQueueIssues(src.Queue)
+	}
+	return m.trackerClient.GetAllBoardIssues(src.Board)
+}
+
+// sourceOf returns the board or queue an issue came from, issues_query for issues
+// only the query found, or empty
+func (m *Manager) sourceOf(issueKey string) string {
+	if source := m.issueSource[issueKey]; source != "" {
+		return source
+	}
+	if m.queryIssues[issueKey] {
+		return querySource
+	}
+	return ""
+}
+
+// sourceLabel returns the source an issue's time is reported under
+func (m *Manager) sourceLabel(issueKey string) string {
+	if source := m.sourceOf(issueKey); source != "" {
+		return source
+	}
+	return otherSource
+}
+
+// sourceSettings returns weights and shares of the configured sources by name
+func (m *Manager) sourceSettings() (map[string]float64, map[string]float64) {
+	weights := make(map[string]float64)
+	shares := make(map[string]float64)
+	for _, src := range m.config.Tracker.GetSources() {
+		weights[src.GetName()] = src.GetWeight()
+		shares[src.GetName()] = src.Share
+	}
+	return weights, shares
+}
+
+// sourceGroup is a set of allocation items sharing one budget of minutes
+type sourceGroup struct {
+	indices []int
+	minutes float64
+}
+
+// splitBySourceShare gives issues of each source with a share their own part of the
+// minutes; the remaining issues split what is left. sources[i] is the source of item i.
+// When no issue without a share is present, shares are scaled to cover all minutes.
+func splitBySourceShare(minutes float64, sources []string, shares map[string]float64) []sourceGroup {
+	shared := make(map[string]*sourceGroup)
+	order := []string{}
+	rest := &sourceGroup{}
+
+	for i, source := range sources {
+		if shares[source] <= 0 {
+			rest.indices = append(rest.indices, i)
+			continue
+		}
+		group, ok := shared[source]
+		if !ok {
+			group = &sourceGroup{}
+			shared[source] = group
+			order = append(order, source)
+		}
+		group.indices = append(group.indices, i)
+	}
+
+	if len(order) == 0 {
+		rest.minutes = minutes
+		return []sourceGroup{*rest}
+	}
+
+	totalShare := 0.0
+	for _, source := range order {
+		totalShare += shares[source]
+	}
+	scale := 1.0
+	if len(rest.indices) == 0 {
+		scale = 1 / totalShare
+	}
+
+	groups := make([]sourceGroup, 0, len(order)+1)
+	for _, source := range order {
+		group := shared[source]
+		group.minutes = minutes * shares[source] * scale
+		groups = append(groups, *group)
+	}
+	if len(rest.indices) > 0 {
+		rest.minutes = minutes * (1 - totalShare)
+		groups = append(groups, *rest)
+	}
+	return groups
+}
+
+// sourceWeight returns the weight of a source; issues from no source weigh 1
+func sourceWeight(weights map[string]float64, source string) float64 {
+	if weight, ok := weights[source]; ok {
+		return weight
+	}
+	return 1
+}
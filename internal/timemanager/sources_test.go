@@ -0,0 +1,74 @@
+package timemanager
+
+import (
+	"math"
+	"testing"
+
+	"github.com/username/time-tracker-bot/internal/config"
+	"go.uber.org/zap"
+)
+
+func TestSplitBySourceShare(t *testing.T) {
+	shares := map[string]float64{"support": 0.25}
+
+	groups := splitBySourceShare(480, []string{"board:1", "support", "", "support"}, shares)
+	if len(groups) != 2 {
+		t.Fatalf("groups = %+v, want 2", groups)
+	}
+	if groups[0].minutes != 120 || len(groups[0].indices) != 2 || groups[0].indices[0] != 1 {
+		t.Errorf("support group = %+v, want indices [1 3] with 120 minutes", groups[0])
+	}
+	if groups[1].minutes != 360 || len(groups[1].indices) != 2 {
+		t.Errorf("rest group = %+v, want indices [0 2] with 360 minutes", groups[1])
+	}
+
+	// Only issues with a share: the share covers the whole day
+	groups = splitBySourceShare(480, []string{"support"}, shares)
+	if len(groups) != 1 || groups[0].minutes != 480 {
+		t.Errorf("groups = %+v, want one group of 480 minutes", groups)
+	}
+
+	// No shares: a single group as before sources existed
+	groups = splitBySourceShare(480, []string{"board:1", ""}, nil)
+	if len(groups) != 1 || groups[0].minutes != 480 || len(groups[0].indices) != 2 {
+		t.Errorf("groups = %+v, want one group of 480 minutes", groups)
+	}
+}
+
+func TestDistributeRemaining_BySource(t *testing.T) {
+	cfg := &config.Config{
+		Tracker: config.TrackerConfig{Sources: []config.TrackerSourceConfig{
+			{Board: 1},
+			{Board: 2, Weight: 3},
+			{Name: "support", Queue: "SUP", Share: 0.25},
+		}},
+	}
+	m := &Manager{
+		config:     cfg,
+		issueRules: NewIssueRules(cfg.TimeRules),
+		logger:     zap.NewNop(),
+		issueSource: map[string]string{
+			"PROJ-1": "board:1",
+			"PROJ-2": "board:2",
+			"SUP-1":  "support",
+		},
+	}
+
+	entries := m.distributeRemaining(480, 480, []string{"PROJ-1", "PROJ-2", "SUP-1"}, nil)
+	got := make(map[string]float64)
+	sources := make(map[string]string)
+	for _, entry := range entries {
+		got[entry.IssueKey] = entry.Minutes
+		sources[entry.IssueKey] = entry.Source
+	}
+
+	want := map[string]float64{"PROJ-1": 90, "PROJ-2": 270, "SUP-1": 120}
+	for key, minutes := range want {
+		if math.Abs(got[key]-minutes) > 1e-6 {
+			t.Errorf("%s minutes = %.1f, want %.1f (all: %v)", key, got[key], minutes, got)
+		}
+	}
+	if sources["PROJ-2"] != "board:2" || sources["SUP-1"] != "support" {
+		t.Errorf("entry sources = %v", sources)
+	}
+}
@@ -0,0 +1,137 @@
+package timemanager
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/username/time-tracker-bot/internal/config"
+)
+
+var (
+	// defaultActiveStatuses keeps the historic behaviour when time_rules.active_statuses is empty
+	defaultActiveStatuses   = []string{"open", "inProgress"}
+	defaultInactiveStatuses = []string{"resolved", "closed"}
+)
+
+// statusSet describes which status keys are worked on and which are known to be idle
+type statusSet struct {
+	active   map[string]bool
+	inactive map[string]bool
+}
+
+// StatusRules decides whether an issue status means "being worked on".
+// Rules are resolved per queue (the prefix of the issue key).
+type StatusRules struct {
+	global statusSet
+	queues map[string]statusSet // upper-case queue key → rules
+}
+
+// NewStatusRules builds status rules from config, falling back to open/inProgress
+func NewStatusRules(cfg config.StatusRulesConfig) *StatusRules {
+	active := cfg.Active
+	inactive := cfg.Inactive
+	if len(active) == 0 && len(inactive) == 0 {
+		active = defaultActiveStatuses
+		inactive = defaultInactiveStatuses
+	}
+
+	rules := &StatusRules{
+		global: newStatusSet(active, inactive),
+		queues: make(map[string]statusSet, len(cfg.Queues)),
+	}
+
+	for queue, queueCfg := range cfg.Queues {
+		queueActive := queueCfg.Active
+		if len(queueActive) == 0 {
+			queueActive = active
+		}
+		queueInactive := queueCfg.Inactive
+		if len(queueInactive) == 0 {
+			queueInactive = inactive
+		}
+		// viper lower-cases map keys, queue keys are compared in upper case
+		rules.queues[strings.ToUpper(queue)] = newStatusSet(queueActive, queueInactive)
+	}
+
+	return rules
+}
+
+func newStatusSet(active, inactive []string) statusSet {
+	set := statusSet{
+		active:   make(map[string]bool, len(active)),
+		inactive: make(map[string]bool, len(inactive)),
+	}
+	for _, status := range active {
+		set.active[status] = true
+	}
+	for _, status := range inactive {
+		set.inactive[status] = true
+	}
+	return set
+}
+
+// IsActive reports whether the status means the issue is being worked on.
+// An empty status (no changelog yet) is treated as active.
+func (r *StatusRules) IsActive(issueKey, status string) bool {
+	if status == "" {
+		return true
+	}
+	return r.setFor(issueKey).active[status]
+}
+
+// IsKnown reports whether the status is mapped either as active or inactive
+func (r *StatusRules) IsKnown(issueKey, status string) bool {
+	if status == "" || status == "unknown" {
+		return true
+	}
+	set := r.setFor(issueKey)
+	return set.active[status] || set.inactive[status]
+}
+
+func (r *StatusRules) setFor(issueKey string) statusSet {
+	if set, ok := r.queues[issueQueue(issueKey)]; ok {
+		return set
+	}
+	return r.global
+}
+
+// UnknownStatuses returns status keys found in timelines that are not mapped, grouped by queue
+func (r *StatusRules) UnknownStatuses(timelines map[string]*StatusTimeline) map[string][]string {
+	found := make(map[string]map[string]bool)
+
+	for issueKey, timeline := range timelines {
+		if timeline == nil {
+			continue
+		}
+		queue := issueQueue(issueKey)
+		for _, change := range timeline.Changes {
+			if r.IsKnown(issueKey, change.Status) {
+				continue
+			}
+			if found[queue] == nil {
+				found[queue] = make(map[string]bool)
+			}
+			found[queue][change.Status] = true
+		}
+	}
+
+	result := make(map[string][]string, len(found))
+	for queue, statuses := range found {
+		keys := make([]string, 0, len(statuses))
+		for status := range statuses {
+			keys = append(keys, status)
+		}
+		sort.Strings(keys)
+		result[queue] = keys
+	}
+
+	return result
+}
+
+// issueQueue extracts queue key from issue key ("PROJ-123" → "PROJ")
+func issueQueue(issueKey string) string {
+	if idx := strings.LastIndex(issueKey, "-"); idx > 0 {
+		return strings.ToUpper(issueKey[:idx])
+	}
+	return strings.ToUpper(issueKey)
+}
@@ -0,0 +1,80 @@
+package timemanager
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/config"
+)
+
+func TestStatusRules_Defaults(t *testing.T) {
+	rules := NewStatusRules(config.StatusRulesConfig{})
+
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{"open", true},
+		{"inProgress", true},
+		{"", true},
+		{"resolved", false},
+		{"review", false},
+	}
+
+	for _, tt := range tests {
+		if got := rules.IsActive("PROJ-1", tt.status); got != tt.want {
+			t.Errorf("IsActive(%q) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestStatusRules_QueueOverride(t *testing.T) {
+	rules := NewStatusRules(config.StatusRulesConfig{
+		Active:   []string{"inProgress"},
+		Inactive: []string{"closed"},
+		Queues: map[string]config.QueueStatusConfig{
+			// viper delivers map keys in lower case
+			"dev": {Active: []string{"inDevelopment", "review"}},
+		},
+	})
+
+	if !rules.IsActive("DEV-10", "review") {
+		t.Error("DEV-10 review should be active for queue override")
+	}
+	if rules.IsActive("DEV-10", "inProgress") {
+		t.Error("DEV-10 inProgress should not be active, queue list replaces global")
+	}
+	if !rules.IsActive("OPS-3", "inProgress") {
+		t.Error("OPS-3 inProgress should be active by global rules")
+	}
+	if !rules.IsKnown("DEV-10", "closed") {
+		t.Error("DEV-10 closed should be known through inherited inactive list")
+	}
+}
+
+func TestStatusRules_UnknownStatuses(t *testing.T) {
+	rules := NewStatusRules(config.StatusRulesConfig{})
+	now := time.Now()
+
+	timelines := map[string]*StatusTimeline{
+		"PROJ-1": {IssueKey: "PROJ-1", Changes: []StatusChange{
+			{Timestamp: now, Status: "inProgress"},
+			{Timestamp: now, Status: "testing"},
+		}},
+		"PROJ-2": {IssueKey: "PROJ-2", Changes: []StatusChange{
+			{Timestamp: now, Status: "review"},
+			{Timestamp: now, Status: "testing"},
+		}},
+		"OPS-1": {IssueKey: "OPS-1", Changes: []StatusChange{
+			{Timestamp: now, Status: "closed"},
+		}},
+	}
+
+	got := rules.UnknownStatuses(timelines)
+	want := map[string][]string{"PROJ": {"review", "testing"}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnknownStatuses() = %v, want %v", got, want)
+	}
+}
@@ -4,125 +4,317 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"time"
 
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/internal/state"
+	"github.com/username/time-tracker-bot/internal/store"
 	"github.com/username/time-tracker-bot/pkg/dateutil"
 	"github.com/username/time-tracker-bot/pkg/random"
 	"go.uber.org/zap"
 )
 
-// WeeklyState represents the weekly schedule state
+// WeeklyState represents the schedule of weekly tasks for one ISO week
 type WeeklyState struct {
-	Year         int                `json:"year"`
-	Week         int                `json:"week"`
-	StartDate    string             `json:"start_date"`
-	EndDate      string             `json:"end_date"`
-	SelectedDays map[string][]string `json:"selected_days"` // task -> [dates]
-	CreatedAt    string             `json:"created_at"`
+	Year         int                 `json:"year"`
+	Week         int                 `json:"week"`
+	StartDate    string              `json:"start_date"`
+	EndDate      string              `json:"end_date"`
+	SelectedDays map[string][]string `json:"selected_days"`           // task -> [dates]
+	TaskMinutes  map[string]float64  `json:"task_minutes,omitempty"`  // task -> minutes per selected day
+	CarriedWeeks []string            `json:"carried_weeks,omitempty"` // earlier weeks without workdays whose hours this week took
+	CreatedAt    string              `json:"created_at"`
+}
+
+// weeklyStateFile is the on-disk format: selections of several weeks keyed by week
+type weeklyStateFile struct {
+	SchemaVersion int                     `json:"schema_version"`
+	Weeks         map[string]*WeeklyState `json:"weeks"` // "2025-W45" -> selection
 }
 
 // WeeklyStateManager manages weekly task scheduling
 type WeeklyStateManager struct {
-	stateFile string
-	state     *WeeklyState
-	logger    *zap.Logger
+	stateFile      string
+	store          store.Store // when set, selections live in the store and stateFile is only imported once
+	retentionWeeks int
+	weeks          map[string]*WeeklyState
+	logger         *zap.Logger
 }
 
-// NewWeeklyStateManager creates a new weekly state manager
-func NewWeeklyStateManager(stateFile string, logger *zap.Logger) *WeeklyStateManager {
+// NewWeeklyStateManager creates a new weekly state manager.
+// Selections older than retentionWeeks are dropped on load.
+func NewWeeklyStateManager(stateFile string, retentionWeeks int, logger *zap.Logger) *WeeklyStateManager {
 	return &WeeklyStateManager{
-		stateFile: stateFile,
-		logger:    logger,
+		stateFile:      stateFile,
+		retentionWeeks: retentionWeeks,
+		weeks:          make(map[string]*WeeklyState),
+		logger:         logger,
 	}
 }
 
-// Load loads the weekly state from file
+// NewStoreWeeklyStateManager creates a weekly state manager backed by a store.
+// Selections from legacyFile are imported when the store has none yet.
+func NewStoreWeeklyStateManager(st store.Store, legacyFile string, retentionWeeks int, logger *zap.Logger) *WeeklyStateManager {
+	wsm := NewWeeklyStateManager(legacyFile, retentionWeeks, logger)
+	wsm.store = st
+	return wsm
+}
+
+// weekKey returns the ISO week key of the date, e.g. "2025-W45"
+func weekKey(date time.Time) string {
+	year, week := dateutil.GetWeekNumber(date)
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// weeklySchemaMigrations upgrade the state file; the latest version is their count
+var weeklySchemaMigrations = []state.Migration{
+	// 0 → 1: the unversioned single-week file becomes {"weeks": {...}}
+	func(doc map[string]json.RawMessage) error {
+		if _, ok := doc["weeks"]; ok {
+			return nil
+		}
+		var legacy WeeklyState
+		raw, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, &legacy); err != nil {
+			return err
+		}
+		weeks := map[string]*WeeklyState{}
+		if legacy.Year != 0 {
+			weeks[fmt.Sprintf("%d-W%02d", legacy.Year, legacy.Week)] = &legacy
+		}
+		encoded, err := json.Marshal(weeks)
+		if err != nil {
+			return err
+		}
+		for key := range doc {
+			delete(doc, key)
+		}
+		doc["weeks"] = encoded
+		return nil
+	},
+}
+
+// Load loads the weekly state, migrating older formats
 func (wsm *WeeklyStateManager) Load() error {
+	var (
+		weeks map[string]*WeeklyState
+		err   error
+	)
+	if wsm.store != nil {
+		weeks, err = wsm.loadStore()
+	} else {
+		weeks, err = wsm.loadFile()
+	}
+	if err != nil {
+		return err
+	}
+
+	// Old weeks are dropped on load only, so a backfill reaching further back
+	// keeps its selections for the whole run
+	wsm.weeks = weeks
+	wsm.prune(time.Now())
+	wsm.logger.Info("Weekly state loaded",
+		zap.Int("weeks", len(wsm.weeks)))
+
+	return nil
+}
+
+// loadStore reads selections from the store, importing the JSON file on first use
+func (wsm *WeeklyStateManager) loadStore() (map[string]*WeeklyState, error) {
+	raw, err := wsm.store.LoadWeeks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load weekly state: %w", err)
+	}
+
+	if len(raw) == 0 && wsm.stateFile != "" {
+		weeks, err := wsm.loadFile()
+		if err != nil {
+			return nil, err
+		}
+		if len(weeks) > 0 {
+			wsm.weeks = weeks
+			if err := wsm.Save(); err != nil {
+				return nil, err
+			}
+			wsm.logger.Info("Imported weekly state file into store",
+				zap.String("file", wsm.stateFile),
+				zap.Int("weeks", len(weeks)))
+		}
+		return weeks, nil
+	}
+
+	weeks := make(map[string]*WeeklyState, len(raw))
+	for key, data := range raw {
+		var week WeeklyState
+		if err := json.Unmarshal(data, &week); err != nil {
+			return nil, fmt.Errorf("failed to parse weekly state %s: %w", key, err)
+		}
+		weeks[key] = &week
+	}
+	return weeks, nil
+}
+
+// loadFile reads selections from the JSON state file
+func (wsm *WeeklyStateManager) loadFile() (map[string]*WeeklyState, error) {
 	data, err := os.ReadFile(wsm.stateFile)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// File doesn't exist yet - will be created on first save
-			wsm.state = &WeeklyState{
-				SelectedDays: make(map[string][]string),
-			}
-			return nil
+			return make(map[string]*WeeklyState), nil
 		}
-		return fmt.Errorf("failed to read state file: %w", err)
+		return nil, fmt.Errorf("failed to read state file: %w", err)
 	}
 
-	var state WeeklyState
-	if err := json.Unmarshal(data, &state); err != nil {
-		return fmt.Errorf("failed to parse state file: %w", err)
+	data, version, err := state.Upgrade(data, weeklySchemaMigrations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade state file: %w", err)
+	}
+	if version < len(weeklySchemaMigrations) {
+		wsm.logger.Info("Weekly state migrated",
+			zap.Int("from_version", version),
+			zap.Int("to_version", len(weeklySchemaMigrations)))
 	}
 
-	wsm.state = &state
-	wsm.logger.Info("Weekly state loaded",
-		zap.Int("year", state.Year),
-		zap.Int("week", state.Week))
+	var file weeklyStateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %w", err)
+	}
+	if file.Weeks == nil {
+		file.Weeks = make(map[string]*WeeklyState)
+	}
 
-	return nil
+	return file.Weeks, nil
 }
 
-// Save saves the weekly state to file
+// Save saves the weekly state to the store or atomically to the state file
 func (wsm *WeeklyStateManager) Save() error {
-	data, err := json.MarshalIndent(wsm.state, "", "  ")
+	if wsm.store != nil {
+		raw := make(map[string]json.RawMessage, len(wsm.weeks))
+		for key, week := range wsm.weeks {
+			data, err := json.Marshal(week)
+			if err != nil {
+				return fmt.Errorf("failed to marshal state: %w", err)
+			}
+			raw[key] = data
+		}
+		if err := wsm.store.SaveWeeks(raw); err != nil {
+			return err
+		}
+		wsm.logger.Info("Weekly state saved",
+			zap.Int("weeks", len(wsm.weeks)))
+		return nil
+	}
+
+	file := weeklyStateFile{
+		SchemaVersion: len(weeklySchemaMigrations),
+		Weeks:         wsm.weeks,
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal state: %w", err)
 	}
 
-	if err := os.WriteFile(wsm.stateFile, data, 0644); err != nil {
+	if err := state.WriteFileAtomic(wsm.stateFile, data, 0644); err != nil {
 		return fmt.Errorf("failed to write state file: %w", err)
 	}
 
 	wsm.logger.Info("Weekly state saved",
-		zap.Int("year", wsm.state.Year),
-		zap.Int("week", wsm.state.Week))
+		zap.Int("weeks", len(wsm.weeks)))
 
 	return nil
 }
 
-// IsNewWeek checks if the given date is in a new week
-func (wsm *WeeklyStateManager) IsNewWeek(date time.Time) bool {
-	year, week := dateutil.GetWeekNumber(date)
+// prune removes weeks that started more than retentionWeeks weeks before now
+func (wsm *WeeklyStateManager) prune(now time.Time) {
+	if wsm.retentionWeeks <= 0 {
+		return
+	}
 
-	if wsm.state == nil || wsm.state.Year == 0 {
-		return true
+	cutoff := dateutil.StartOfWeek(now).AddDate(0, 0, -7*wsm.retentionWeeks).Format("2006-01-02")
+	for key, week := range wsm.weeks {
+		if week.StartDate < cutoff {
+			delete(wsm.weeks, key)
+		}
 	}
+}
 
-	return year != wsm.state.Year || week != wsm.state.Week
+// IsNewWeek checks whether no selection is stored yet for the week of the date
+func (wsm *WeeklyStateManager) IsNewWeek(date time.Time) bool {
+	_, ok := wsm.weeks[weekKey(date)]
+	return !ok
 }
 
-// SelectDaysForWeek selects random days for weekly tasks
-func (wsm *WeeklyStateManager) SelectDaysForWeek(date time.Time, weeklyTasks map[string]int) error {
+// SelectDaysForWeek selects random days for weekly tasks among the given workdays.
+// workdays are the remaining calendar workdays of the week (holidays excluded,
+// transferred working Saturdays included). When there are fewer workdays than
+// days_per_week the weekly hours are compressed into the available days.
+// carriedWeeks are earlier weeks without workdays (see Manager.holidayWeeksBefore):
+// they never get a selection, so their weekly hours are added to this week.
+func (wsm *WeeklyStateManager) SelectDaysForWeek(date time.Time, weeklyTasks []config.WeeklyTaskConfig, workdays []time.Time, carriedWeeks []string) error {
+	if len(workdays) == 0 {
+		return fmt.Errorf("no workdays to select in week %s", weekKey(date))
+	}
+
 	year, week := dateutil.GetWeekNumber(date)
 
 	// Get start and end of week
 	monday := dateutil.StartOfWeek(date)
 	sunday := dateutil.EndOfWeek(date)
 
-	wsm.state = &WeeklyState{
+	ws := &WeeklyState{
 		Year:         year,
 		Week:         week,
 		StartDate:    monday.Format("2006-01-02"),
 		EndDate:      sunday.Format("2006-01-02"),
 		SelectedDays: make(map[string][]string),
+		TaskMinutes:  make(map[string]float64),
+		CarriedWeeks: carriedWeeks,
 		CreatedAt:    time.Now().Format(time.RFC3339),
 	}
+	wsm.weeks[weekKey(date)] = ws
 
 	// Select random days for each task
-	for taskKey, daysPerWeek := range weeklyTasks {
-		dates := random.SelectRandomWeekdayDates(date, daysPerWeek)
+	for _, task := range weeklyTasks {
+		weeklyMinutes := task.HoursPerWeek * 60 * float64(1+len(carriedWeeks))
+		if len(carriedWeeks) > 0 {
+			wsm.logger.Warn("Weeks without workdays, their weekly task hours move to this week",
+				zap.String("week", weekKey(date)),
+				zap.Strings("carried_weeks", carriedWeeks),
+				zap.String("task", task.Issue),
+				zap.Float64("minutes", weeklyMinutes))
+		}
+
+		daysPerWeek := task.DaysPerWeek
+		if daysPerWeek <= 0 {
+			daysPerWeek = 1
+		}
+		if daysPerWeek > len(workdays) {
+			wsm.logger.Info("Short week, compressing weekly task into fewer days",
+				zap.String("task", task.Issue),
+				zap.Int("days_per_week", daysPerWeek),
+				zap.Int("workdays", len(workdays)))
+			daysPerWeek = len(workdays)
+		}
+
+		dates := random.SelectRandomDates(workdays, daysPerWeek)
 
 		dateStrings := make([]string, len(dates))
 		for i, d := range dates {
 			dateStrings[i] = d.Format("2006-01-02")
 		}
 
-		wsm.state.SelectedDays[taskKey] = dateStrings
+		ws.SelectedDays[task.Issue] = dateStrings
+		ws.TaskMinutes[task.Issue] = weeklyMinutes / float64(len(dates))
 
 		wsm.logger.Info("Selected random days for weekly task",
-			zap.String("task", taskKey),
+			zap.String("week", weekKey(date)),
+			zap.String("task", task.Issue),
 			zap.Int("days_per_week", daysPerWeek),
+			zap.Float64("minutes_per_day", ws.TaskMinutes[task.Issue]),
 			zap.Strings("selected_dates", dateStrings))
 	}
 
@@ -131,17 +323,8 @@ func (wsm *WeeklyStateManager) SelectDaysForWeek(date time.Time, weeklyTasks map
 
 // IsSelectedDay checks if the given date is selected for the task
 func (wsm *WeeklyStateManager) IsSelectedDay(date time.Time, taskKey string) bool {
-	if wsm.state == nil {
-		return false
-	}
-
 	dateStr := date.Format("2006-01-02")
-	selectedDates, ok := wsm.state.SelectedDays[taskKey]
-	if !ok {
-		return false
-	}
-
-	for _, d := range selectedDates {
+	for _, d := range wsm.GetSelectedDays(date, taskKey) {
 		if d == dateStr {
 			return true
 		}
@@ -150,16 +333,39 @@ func (wsm *WeeklyStateManager) IsSelectedDay(date time.Time, taskKey string) boo
 	return false
 }
 
-// GetSelectedDays returns all selected days for the task
-func (wsm *WeeklyStateManager) GetSelectedDays(taskKey string) []string {
-	if wsm.state == nil {
+// GetTaskMinutes returns minutes to log on each selected day of the task in the week of date.
+// ok is false for weeks saved before per-day minutes were stored.
+func (wsm *WeeklyStateManager) GetTaskMinutes(date time.Time, taskKey string) (float64, bool) {
+	week, ok := wsm.weeks[weekKey(date)]
+	if !ok {
+		return 0, false
+	}
+
+	minutes, ok := week.TaskMinutes[taskKey]
+	return minutes, ok
+}
+
+// GetSelectedDays returns selected days of the task in the week of date
+func (wsm *WeeklyStateManager) GetSelectedDays(date time.Time, taskKey string) []string {
+	week, ok := wsm.weeks[weekKey(date)]
+	if !ok {
 		return []string{}
 	}
 
-	return wsm.state.SelectedDays[taskKey]
+	return week.SelectedDays[taskKey]
+}
+
+// GetWeek returns the selection stored for the week of date, or nil
+func (wsm *WeeklyStateManager) GetWeek(date time.Time) *WeeklyState {
+	return wsm.weeks[weekKey(date)]
 }
 
-// GetCurrentState returns current state
-func (wsm *WeeklyStateManager) GetCurrentState() *WeeklyState {
-	return wsm.state
+// Weeks returns stored week keys in chronological order
+func (wsm *WeeklyStateManager) Weeks() []string {
+	keys := make([]string, 0, len(wsm.weeks))
+	for key := range wsm.weeks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
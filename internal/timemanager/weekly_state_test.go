@@ -0,0 +1,231 @@
+package timemanager
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/username/time-tracker-bot/internal/calendar"
+	"github.com/username/time-tracker-bot/internal/config"
+	"github.com/username/time-tracker-bot/internal/store"
+	"go.uber.org/zap"
+)
+
+func TestSelectDaysForWeek_HolidayWeek(t *testing.T) {
+	wsm := NewWeeklyStateManager(filepath.Join(t.TempDir(), "weekly.json"), 0, zap.NewNop())
+	if err := wsm.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only Wednesday and a transferred working Saturday are workdays
+	workdays := []time.Time{
+		time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 8, 0, 0, 0, 0, time.UTC),
+	}
+	tasks := []config.WeeklyTaskConfig{{Issue: "PROJ-201", HoursPerWeek: 6, DaysPerWeek: 3}}
+
+	if err := wsm.SelectDaysForWeek(workdays[0], tasks, workdays, nil); err != nil {
+		t.Fatalf("SelectDaysForWeek() error = %v", err)
+	}
+
+	for _, d := range workdays {
+		if !wsm.IsSelectedDay(d, "PROJ-201") {
+			t.Errorf("%s not selected, want both workdays", d.Format("2006-01-02"))
+		}
+	}
+	if minutes, ok := wsm.GetTaskMinutes(workdays[0], "PROJ-201"); !ok || math.Abs(minutes-180) > 1e-9 {
+		t.Errorf("GetTaskMinutes() = %v, %v; want 180 (6h compressed into 2 days)", minutes, ok)
+	}
+}
+
+func TestDistributeWeeklyTasks_HolidayWeekCarried(t *testing.T) {
+	wsm := NewWeeklyStateManager(filepath.Join(t.TempDir(), "weekly.json"), 0, zap.NewNop())
+	if err := wsm.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	// New year holidays: no workdays in the week of Jan 5, 2026
+	off := make(map[string]bool)
+	for d := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC); d.Day() <= 9; d = d.AddDate(0, 0, 1) {
+		off[d.Format("2006-01-02")] = true
+	}
+	cfg := &config.Config{TimeRules: config.TimeRulesConfig{
+		WeeklyTasks: []config.WeeklyTaskConfig{{Issue: "PROJ-201", HoursPerWeek: 2, DaysPerWeek: 2}},
+	}}
+	m := &Manager{
+		config:       cfg,
+		calendarDays: newCalendarDays(&countingCalendar{off: off}),
+		weeklyState:  wsm,
+		schedule:     NewSchedule(cfg.TimeRules),
+		ctx:          context.Background(),
+		logger:       zap.NewNop(),
+	}
+
+	nextMonday := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	if _, _, err := m.distributeWeeklyTasks(nextMonday); err != nil {
+		t.Fatalf("distributeWeeklyTasks() error = %v", err)
+	}
+
+	if minutes, _ := wsm.GetTaskMinutes(nextMonday, "PROJ-201"); math.Abs(minutes-120) > 1e-9 {
+		t.Errorf("GetTaskMinutes() = %v, want 120 (2h + 2h of the holiday week, 2 days)", minutes)
+	}
+	if got := len(wsm.GetSelectedDays(nextMonday, "PROJ-201")); got != 2 {
+		t.Errorf("selected %d days, want 2", got)
+	}
+	if week := wsm.GetWeek(nextMonday); len(week.CarriedWeeks) != 1 || week.CarriedWeeks[0] != "2026-W02" {
+		t.Errorf("CarriedWeeks = %v, want [2026-W02]", week.CarriedWeeks)
+	}
+
+	// The week after takes only its own hours
+	if _, _, err := m.distributeWeeklyTasks(nextMonday.AddDate(0, 0, 7)); err != nil {
+		t.Fatal(err)
+	}
+	if minutes, _ := wsm.GetTaskMinutes(nextMonday.AddDate(0, 0, 7), "PROJ-201"); math.Abs(minutes-60) > 1e-9 {
+		t.Errorf("GetTaskMinutes() a week later = %v, want 60", minutes)
+	}
+}
+
+func TestDistributeWeeklyTasks_VacationWeekNotCarried(t *testing.T) {
+	wsm := NewWeeklyStateManager(filepath.Join(t.TempDir(), "weekly.json"), 0, zap.NewNop())
+	if err := wsm.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Vacation in the week of Jan 12, 2026: no workdays, but not a calendar holiday week
+	vacation := []calendar.TimeOff{{
+		From: time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC),
+		Kind: calendar.TimeOffVacation,
+	}}
+	overlay := calendar.NewOverlayCalendar(&countingCalendar{}, vacation, nil, zap.NewNop())
+	cfg := &config.Config{TimeRules: config.TimeRulesConfig{
+		WeeklyTasks: []config.WeeklyTaskConfig{{Issue: "PROJ-201", HoursPerWeek: 2, DaysPerWeek: 2}},
+	}}
+	m := &Manager{
+		config:       cfg,
+		calendar:     overlay,
+		calendarDays: newCalendarDays(overlay),
+		weeklyState:  wsm,
+		schedule:     NewSchedule(cfg.TimeRules),
+		ctx:          context.Background(),
+		logger:       zap.NewNop(),
+	}
+
+	backMonday := time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC)
+	if _, _, err := m.distributeWeeklyTasks(backMonday); err != nil {
+		t.Fatalf("distributeWeeklyTasks() error = %v", err)
+	}
+	if minutes, _ := wsm.GetTaskMinutes(backMonday, "PROJ-201"); math.Abs(minutes-60) > 1e-9 {
+		t.Errorf("GetTaskMinutes() = %v, want 60 (the vacation week is not carried)", minutes)
+	}
+	if week := wsm.GetWeek(backMonday); len(week.CarriedWeeks) != 0 {
+		t.Errorf("CarriedWeeks = %v, want none", week.CarriedWeeks)
+	}
+}
+
+func TestWeeklyState_KeepsEarlierWeeks(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "weekly.json")
+	wsm := NewWeeklyStateManager(stateFile, 0, zap.NewNop())
+	if err := wsm.Load(); err != nil {
+		t.Fatal(err)
+	}
+	tasks := []config.WeeklyTaskConfig{{Issue: "PROJ-201", HoursPerWeek: 2, DaysPerWeek: 1}}
+
+	current := []time.Time{time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC)}
+	previous := []time.Time{time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC)}
+
+	if err := wsm.SelectDaysForWeek(current[0], tasks, current, nil); err != nil {
+		t.Fatal(err)
+	}
+	// Backfill reaches the previous week afterwards
+	if !wsm.IsNewWeek(previous[0]) {
+		t.Fatal("IsNewWeek() = false for a week without selection")
+	}
+	if err := wsm.SelectDaysForWeek(previous[0], tasks, previous, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := NewWeeklyStateManager(stateFile, 0, zap.NewNop())
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range []time.Time{current[0], previous[0]} {
+		if reloaded.IsNewWeek(d) || !reloaded.IsSelectedDay(d, "PROJ-201") {
+			t.Errorf("selection for %s lost", d.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestWeeklyState_MigratesSingleWeekFile(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "weekly.json")
+	legacy := `{"year": 2025, "week": 46, "start_date": "2025-11-10", "end_date": "2025-11-16",
+		"selected_days": {"PROJ-201": ["2025-11-11"]}, "created_at": "2025-11-10T10:00:00Z"}`
+	if err := os.WriteFile(stateFile, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wsm := NewWeeklyStateManager(stateFile, 0, zap.NewNop())
+	if err := wsm.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if !wsm.IsSelectedDay(time.Date(2025, 11, 11, 0, 0, 0, 0, time.UTC), "PROJ-201") {
+		t.Error("legacy selection not migrated")
+	}
+}
+
+func TestWeeklyState_PruneRetention(t *testing.T) {
+	wsm := NewWeeklyStateManager(filepath.Join(t.TempDir(), "weekly.json"), 4, zap.NewNop())
+	wsm.weeks["2025-W30"] = &WeeklyState{StartDate: "2025-07-21"}
+	wsm.weeks["2025-W44"] = &WeeklyState{StartDate: "2025-10-27"}
+
+	wsm.prune(time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC))
+
+	if got := wsm.Weeks(); len(got) != 1 || got[0] != "2025-W44" {
+		t.Errorf("Weeks() after prune = %v, want [2025-W44]", got)
+	}
+}
+
+func TestWeeklyState_StoreImportsLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	stateFile := filepath.Join(dir, "weekly.json")
+
+	fileState := NewWeeklyStateManager(stateFile, 0, zap.NewNop())
+	if err := fileState.Load(); err != nil {
+		t.Fatal(err)
+	}
+	day := []time.Time{time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC)}
+	tasks := []config.WeeklyTaskConfig{{Issue: "PROJ-201", HoursPerWeek: 2, DaysPerWeek: 1}}
+	if err := fileState.SelectDaysForWeek(day[0], tasks, day, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := store.OpenBolt(filepath.Join(dir, "bot.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	storeState := NewStoreWeeklyStateManager(st, stateFile, 0, zap.NewNop())
+	if err := storeState.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !storeState.IsSelectedDay(day[0], "PROJ-201") {
+		t.Error("selection not imported from the JSON file")
+	}
+
+	// Second load reads from the store itself
+	if err := os.Remove(stateFile); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := NewStoreWeeklyStateManager(st, stateFile, 0, zap.NewNop())
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.IsSelectedDay(day[0], "PROJ-201") {
+		t.Error("selection not persisted in the store")
+	}
+}
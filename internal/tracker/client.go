@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -18,11 +19,14 @@ const (
 	defaultTimeout    = 30 * time.Second
 	defaultRetries    = 3
 	worklogSearchPath = "/v2/worklog/_search"
+	issueSearchPath   = "/v2/issues/_search"
 	// По офдоку «Постраничное отображение результатов» (https://yandex.ru/support/tracker/ru/common-format#displaying-results)
 	// API принимает параметры page/perPage. Максимум не документирован, но 100 стабильно отдаётся Трекером, используем это значение.
 	// Tracker API возвращает максимум 50 записей на страницу, даже если запросить больше
 	// (см. https://yandex.ru/support/tracker/ru/common-format#displaying-results).
 	worklogPageSize = 50
+	issuesPageSize  = 50
+	issuesBatchSize = 50
 )
 
 // Client represents Yandex Tracker API client
@@ -54,8 +58,7 @@ func (c *Client) SearchIssues(query string) ([]Issue, error) {
 		Query: query,
 	}
 
-	var issues []Issue
-	err := c.doRequest("POST", "/v2/issues/_search", req, &issues)
+	issues, err := c.fetchAllIssues(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search issues: %w", err)
 	}
@@ -67,13 +70,82 @@ func (c *Client) SearchIssues(query string) ([]Issue, error) {
 	return issues, nil
 }
 
+// GetIssues loads issues by their keys (batched to keep requests small)
+func (c *Client) GetIssues(keys []string) ([]Issue, error) {
+	var issues []Issue
+
+	for start := 0; start < len(keys); start += issuesBatchSize {
+		end := start + issuesBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		req := SearchIssuesRequest{
+			Keys: keys[start:end],
+		}
+
+		batch, err := c.fetchAllIssues(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issues: %w", err)
+		}
+		issues = append(issues, batch...)
+	}
+
+	c.logger.Info("Issues loaded by keys",
+		zap.Int("requested", len(keys)),
+		zap.Int("count", len(issues)))
+
+	return issues, nil
+}
+
+// fetchAllIssues pages through issue search results until a short page comes back
+func (c *Client) fetchAllIssues(req SearchIssuesRequest) ([]Issue, error) {
+	page := 1
+	var allIssues []Issue
+
+	for {
+		params := url.Values{}
+		params.Set("page", strconv.Itoa(page))
+		params.Set("perPage", strconv.Itoa(issuesPageSize))
+		pathWithQuery := fmt.Sprintf("%s?%s", issueSearchPath, params.Encode())
+
+		var batch []Issue
+		if err := c.doRequest("POST", pathWithQuery, req, &batch); err != nil {
+			return nil, err
+		}
+
+		allIssues = append(allIssues, batch...)
+
+		if len(batch) < issuesPageSize {
+			break
+		}
+
+		page++
+	}
+
+	return allIssues, nil
+}
+
 // GetAllBoardIssues returns all issues from board regardless of status
 func (c *Client) GetAllBoardIssues(boardID int) ([]Issue, error) {
-	// Query: get all issues from board, assigned to current user
-	// No status filter - includes all statuses (open, in progress, closed, etc.)
-	query := fmt.Sprintf("Boards: %d AND Assignee: me()", boardID)
+	return c.SearchIssues(BoardQuery(boardID))
+}
+
+// BoardQuery returns the query for all issues of the board assigned to the current user.
+// No status filter - includes all statuses (open, in progress, closed, etc.)
+func BoardQuery(boardID int) string {
+	return fmt.Sprintf("Boards: %d AND Assignee: me()", boardID)
+}
+
+// GetQueueIssues returns all issues of the queue assigned to the current user regardless
+// of status, loading every page of the search
+func (c *Client) GetQueueIssues(queue string) ([]Issue, error) {
+	return c.SearchIssues(QueueQuery(queue))
+}
 
-	return c.SearchIssues(query)
+// QueueQuery returns the query for all issues of the queue assigned to the current user
+func QueueQuery(queue string) string {
+	return fmt.Sprintf("Queue: %s AND Assignee: me()", queue)
 }
 
 // GetCurrentUser returns current authenticated user info (cached)
@@ -590,15 +662,16 @@ func ParseISO8601Duration(duration string) (float64, error) {
 	return minutes, nil
 }
 
-// FormatDuration formats minutes to ISO 8601 duration
-// Examples: 480 -> PT8H, 90 -> PT1H30M, 45 -> PT45M
+// FormatDuration formats minutes to ISO 8601 duration, rounding to the nearest minute
+// Examples: 480 -> PT8H, 90 -> PT1H30M, 45 -> PT45M, 89.6 -> PT1H30M
 func FormatDuration(minutes float64) string {
-	if minutes == 0 {
+	total := int(math.Round(minutes))
+	if total <= 0 {
 		return "PT0M"
 	}
 
-	hours := int(minutes / 60)
-	mins := int(minutes) % 60
+	hours := total / 60
+	mins := total % 60
 
 	if hours > 0 && mins > 0 {
 		return fmt.Sprintf("PT%dH%dM", hours, mins)
@@ -1,7 +1,16 @@
 package tracker
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+
+	"go.uber.org/zap"
 )
 
 func TestParseISO8601Duration(t *testing.T) {
@@ -53,6 +62,8 @@ func TestFormatDuration(t *testing.T) {
 		{"10 minutes", 10, "PT10M"},
 		{"0 minutes", 0, "PT0M"},
 		{"2 hours exactly", 120, "PT2H"},
+		{"fraction rounds up", 89.6, "PT1H30M"},
+		{"fraction rounds down", 44.4, "PT44M"},
 	}
 
 	for _, tt := range tests {
@@ -87,3 +98,55 @@ func TestFormatDurationRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+func TestIssueEstimateMinutes(t *testing.T) {
+	issue := Issue{OriginalEstimation: "P1D", Estimation: "PT4H", Spent: "PT1H30M"}
+	if got := issue.EstimateMinutes(); got != 240 {
+		t.Errorf("EstimateMinutes() = %v, want 240 (current estimate wins)", got)
+	}
+	if got := issue.SpentMinutes(); got != 90 {
+		t.Errorf("SpentMinutes() = %v, want 90", got)
+	}
+
+	issue = Issue{OriginalEstimation: "P1D"}
+	if got := issue.EstimateMinutes(); got != 480 {
+		t.Errorf("EstimateMinutes() = %v, want 480 from the original estimate", got)
+	}
+	if got := (&Issue{}).EstimateMinutes(); got != 0 {
+		t.Errorf("EstimateMinutes() without estimates = %v, want 0", got)
+	}
+}
+
+func TestGetQueueIssues_Paginates(t *testing.T) {
+	const total = issuesPageSize + 20
+	var pages []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "perPage") {
+			t.Errorf("perPage sent in the request body: %s", body)
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("perPage"))
+		pages = append(pages, r.URL.Query().Get("page"))
+
+		issues := []Issue{}
+		for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+			issues = append(issues, Issue{Key: fmt.Sprintf("SUP-%d", i+1)})
+		}
+		_ = json.NewEncoder(w).Encode(issues)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "org", &TokenManager{token: "token"}, zap.NewNop())
+	issues, err := client.GetQueueIssues("SUP")
+	if err != nil {
+		t.Fatalf("GetQueueIssues() error = %v", err)
+	}
+	if len(issues) != total || issues[total-1].Key != fmt.Sprintf("SUP-%d", total) {
+		t.Errorf("GetQueueIssues() returned %d issues, want %d", len(issues), total)
+	}
+	if strings.Join(pages, ",") != "1,2" {
+		t.Errorf("pages requested = %v, want [1 2]", pages)
+	}
+}
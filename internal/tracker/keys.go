@@ -0,0 +1,28 @@
+package tracker
+
+import (
+	"regexp"
+	"strings"
+)
+
+// issueKeyPattern matches issue keys such as PROJ-123
+var issueKeyPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]*-[0-9]+\b`)
+
+// FindIssueKeys returns the issue keys mentioned in text, in order, without duplicates
+func FindIssueKeys(text string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range issueKeyPattern.FindAllString(text, -1) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// QueueOf returns the queue of an issue key ("PROJ" for "PROJ-123")
+func QueueOf(issueKey string) string {
+	queue, _, _ := strings.Cut(issueKey, "-")
+	return queue
+}
@@ -94,9 +94,58 @@ type Issue struct {
 	Type       *IssueType   `json:"type,omitempty"`
 	Status     Status       `json:"status"`
 	Assignee   *User        `json:"assignee,omitempty"`
+	Queue      *QueueRef    `json:"queue,omitempty"`
+	Tags       []string     `json:"tags,omitempty"`
+	Components []Component  `json:"components,omitempty"`
 	CreatedAt  TrackerTime  `json:"createdAt"`
 	UpdatedAt  TrackerTime  `json:"updatedAt"`
 	ResolvedAt *TrackerTime `json:"resolvedAt,omitempty"`
+
+	// ISO 8601 durations in business time (1D = 8h), empty when not set
+	OriginalEstimation string `json:"originalEstimation,omitempty"`
+	Estimation         string `json:"estimation,omitempty"`
+	Spent              string `json:"spent,omitempty"`
+}
+
+// EstimateMinutes returns the current estimate, or the original one when there is
+// none, in minutes; 0 when the issue is not estimated
+func (i *Issue) EstimateMinutes() float64 {
+	for _, value := range []string{i.Estimation, i.OriginalEstimation} {
+		if value == "" {
+			continue
+		}
+		if minutes, err := ParseISO8601Duration(value); err == nil && minutes > 0 {
+			return minutes
+		}
+	}
+	return 0
+}
+
+// SpentMinutes returns time logged to the issue in minutes
+func (i *Issue) SpentMinutes() float64 {
+	if i.Spent == "" {
+		return 0
+	}
+	minutes, err := ParseISO8601Duration(i.Spent)
+	if err != nil {
+		return 0
+	}
+	return minutes
+}
+
+// QueueRef represents a reference to a queue
+type QueueRef struct {
+	Self    string     `json:"self"`
+	ID      FlexibleID `json:"id"`
+	Key     string     `json:"key"`
+	Display string     `json:"display"`
+}
+
+// Component represents an issue component
+type Component struct {
+	Self    string     `json:"self"`
+	ID      FlexibleID `json:"id"`
+	Display string     `json:"display"`
 }
 
 // IssueType represents issue type (Task, Epic, Bug, etc.)
@@ -143,11 +192,11 @@ type IssueRef struct {
 
 // SearchIssuesRequest represents request to search issues
 type SearchIssuesRequest struct {
-	Query   string                 `json:"query,omitempty"`
-	Filter  map[string]interface{} `json:"filter,omitempty"`
-	Order   string                 `json:"order,omitempty"`
-	Expand  string                 `json:"expand,omitempty"`
-	PerPage int                    `json:"perPage,omitempty"`
+	Query  string                 `json:"query,omitempty"`
+	Keys   []string               `json:"keys,omitempty"`
+	Filter map[string]interface{} `json:"filter,omitempty"`
+	Order  string                 `json:"order,omitempty"`
+	Expand string                 `json:"expand,omitempty"`
 }
 
 // SearchWorklogsRequest represents request to search worklogs
@@ -180,6 +229,9 @@ type TimeEntry struct {
 	IssueKey string
 	Minutes  float64
 	Comment  string
+	Start    time.Time // Optional anchor; zero means the day layout picks the start
+	Fixed    bool      // Real duration (a meeting): normalization and rounding leave Minutes as is
+	Source   string    // Board or queue the issue was picked from; empty when not from a source
 }
 
 // ChangelogEntry represents a single change in issue history
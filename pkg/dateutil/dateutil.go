@@ -1,6 +1,10 @@
 package dateutil
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // StartOfDay returns the start of the day (00:00:00) for the given date
 func StartOfDay(date time.Time) time.Time {
@@ -86,6 +90,48 @@ func ParseDate(dateStr string) (time.Time, error) {
 	return time.Time{}, nil
 }
 
+// ParseClock parses "HH:MM" time of day and returns minutes since midnight
+func ParseClock(clock string) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(clock, "%d:%d", &h, &m); err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", clock)
+	}
+	if h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", clock)
+	}
+	return h*60 + m, nil
+}
+
+// AtMinute returns the date at the given minute of the day in the date's location
+func AtMinute(date time.Time, minuteOfDay int) time.Time {
+	return StartOfDay(date).Add(time.Duration(minuteOfDay) * time.Minute)
+}
+
+// ParseWeekday parses an English weekday name, full or abbreviated ("mon", "Monday")
+func ParseWeekday(name string) (time.Weekday, error) {
+	value := strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if value == full || value == full[:3] {
+			return day, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("invalid weekday %q", name)
+}
+
+// WeeksBetween returns the number of whole weeks from the week of from to the week of to
+func WeeksBetween(from, to time.Time) int {
+	fromMonday := StartOfWeek(from)
+	toMonday := StartOfWeek(to)
+	fromDay := time.Date(fromMonday.Year(), fromMonday.Month(), fromMonday.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(toMonday.Year(), toMonday.Month(), toMonday.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(toDay.Sub(fromDay).Hours() / 24)
+	if days < 0 {
+		return -((-days + 6) / 7)
+	}
+	return days / 7
+}
+
 // Today returns today's date (start of day)
 func Today() time.Time {
 	return StartOfDay(time.Now())
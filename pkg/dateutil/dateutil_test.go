@@ -235,3 +235,74 @@ func TestParseDate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"10:00", 600, false},
+		{"09:30", 570, false},
+		{"0:05", 5, false},
+		{"24:00", 0, true},
+		{"10:60", 0, true},
+		{"noon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseClock(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseClock(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseClock(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseWeekday(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Weekday
+		wantErr bool
+	}{
+		{"mon", time.Monday, false},
+		{"Friday", time.Friday, false},
+		{" SUN ", time.Sunday, false},
+		{"fr", time.Sunday, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseWeekday(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseWeekday(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseWeekday(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestWeeksBetween(t *testing.T) {
+	anchor := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC) // Wednesday
+
+	tests := []struct {
+		date time.Time
+		want int
+	}{
+		{time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), 0},  // same week, Monday
+		{time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC), 0}, // same week, Sunday
+		{time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC), 1},
+		{time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), 12}, // across DST-free months
+		{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), -1},
+	}
+
+	for _, tt := range tests {
+		if got := WeeksBetween(anchor, tt.date); got != tt.want {
+			t.Errorf("WeeksBetween(%s) = %d, want %d", tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
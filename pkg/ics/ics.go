@@ -0,0 +1,423 @@
+package ics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Property is one content line of a component, e.g. DTSTART;TZID=Europe/Moscow:20251103T100000
+type Property struct {
+	Name   string            // Upper case
+	Params map[string]string // Upper-case names, unquoted values
+	Value  string            // Unescaped for text properties
+}
+
+// Event is a VEVENT. A recurring event is returned once, as its first
+// occurrence with RRule set; Expand turns it into instances.
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	Status       string // Upper case: CONFIRMED, TENTATIVE, CANCELLED
+	Start        time.Time
+	End          time.Time // Exclusive; for all-day events the day after the last day
+	AllDay       bool
+	Categories   []string
+	Attendees    []string // E-mail addresses without mailto:
+	Organizer    string   // E-mail address without mailto:
+	RRule        string
+	ExDates      []time.Time
+	RecurrenceID time.Time // Set on an instance that overrides one occurrence of UID
+	Properties   []Property
+}
+
+// Get returns the value of the first property with the name, or ""
+func (e *Event) Get(name string) string {
+	name = strings.ToUpper(name)
+	for _, prop := range e.Properties {
+		if prop.Name == name {
+			return prop.Value
+		}
+	}
+	return ""
+}
+
+// Days returns the dates an all-day event covers, at midnight UTC
+func (e *Event) Days() []time.Time {
+	var days []time.Time
+	for day := e.Start; day.Before(e.End); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+// ParseFile reads events from an .ics file
+func ParseFile(path string) ([]Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ics file: %w", err)
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// Parse reads the VEVENTs of an iCalendar stream (RFC 5545). Properties of
+// nested components such as VALARM are ignored.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events  []Event
+		current *Event
+		nested  int // Depth of components inside the current VEVENT
+	)
+
+	for i, line := range lines {
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch prop.Name {
+		case "BEGIN":
+			if current != nil {
+				nested++
+			} else if strings.EqualFold(prop.Value, "VEVENT") {
+				current = &Event{}
+			}
+			continue
+		case "END":
+			if current == nil {
+				continue
+			}
+			if nested > 0 {
+				nested--
+				continue
+			}
+			if err := current.finish(); err != nil {
+				return nil, fmt.Errorf("event %q: %w", current.Summary, err)
+			}
+			events = append(events, *current)
+			current = nil
+			continue
+		}
+
+		if current == nil || nested > 0 {
+			continue
+		}
+		if err := current.set(prop); err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", i+1, prop.Name, err)
+		}
+	}
+
+	if current != nil {
+		return nil, fmt.Errorf("unterminated VEVENT %q", current.Summary)
+	}
+	return events, nil
+}
+
+// set applies a property to the event
+func (e *Event) set(prop Property) error {
+	switch prop.Name {
+	case "UID":
+		e.UID = prop.Value
+	case "SUMMARY":
+		e.Summary = prop.Value
+	case "DESCRIPTION":
+		e.Description = prop.Value
+	case "LOCATION":
+		e.Location = prop.Value
+	case "CATEGORIES":
+		e.Categories = append(e.Categories, splitList(prop.Value)...)
+	case "ATTENDEE":
+		e.Attendees = append(e.Attendees, mailAddress(prop.Value))
+	case "ORGANIZER":
+		e.Organizer = mailAddress(prop.Value)
+	case "STATUS":
+		e.Status = strings.ToUpper(prop.Value)
+	case "RRULE":
+		e.RRule = prop.Value
+	case "EXDATE":
+		for _, value := range strings.Split(prop.Value, ",") {
+			exDate, _, err := parseTime(Property{Params: prop.Params, Value: value})
+			if err != nil {
+				return err
+			}
+			e.ExDates = append(e.ExDates, exDate)
+		}
+	case "RECURRENCE-ID":
+		recurrenceID, _, err := parseTime(prop)
+		if err != nil {
+			return err
+		}
+		e.RecurrenceID = recurrenceID
+	case "DTSTART":
+		start, allDay, err := parseTime(prop)
+		if err != nil {
+			return err
+		}
+		e.Start, e.AllDay = start, allDay
+	case "DTEND":
+		end, _, err := parseTime(prop)
+		if err != nil {
+			return err
+		}
+		e.End = end
+	}
+
+	e.Properties = append(e.Properties, prop)
+	return nil
+}
+
+// finish fills End from DURATION or the RFC 5545 defaults
+func (e *Event) finish() error {
+	if e.Start.IsZero() {
+		return fmt.Errorf("DTSTART is required")
+	}
+	if !e.End.IsZero() {
+		return nil
+	}
+
+	if value := e.Get("DURATION"); value != "" {
+		duration, err := ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		if e.AllDay {
+			e.End = e.Start.AddDate(0, 0, int(duration/(24*time.Hour)))
+		} else {
+			e.End = e.Start.Add(duration)
+		}
+		return nil
+	}
+
+	if e.AllDay {
+		e.End = e.Start.AddDate(0, 0, 1)
+	} else {
+		e.End = e.Start
+	}
+	return nil
+}
+
+// unfold joins continuation lines (starting with a space or tab) to the previous line
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ics: %w", err)
+	}
+	return lines, nil
+}
+
+// parseLine splits a content line into name, parameters and value
+func parseLine(line string) (Property, error) {
+	prop := Property{Params: make(map[string]string)}
+
+	// The value starts at the first colon outside a quoted parameter value
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return prop, fmt.Errorf("missing ':' in %q", line)
+	}
+
+	head := splitParams(line[:colon])
+	prop.Name = strings.ToUpper(head[0])
+	for _, param := range head[1:] {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+		prop.Params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+
+	prop.Value = line[colon+1:]
+	if isText(prop.Name) {
+		prop.Value = unescape(prop.Value)
+	}
+	return prop, nil
+}
+
+// splitParams splits "NAME;A=1;B="x;y"" at semicolons outside quotes
+func splitParams(head string) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i, r := range head {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ';' && !quoted:
+			parts = append(parts, head[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, head[start:])
+}
+
+// isText reports whether the property holds escaped text. CATEGORIES is
+// unescaped per item by splitList.
+func isText(name string) bool {
+	switch name {
+	case "SUMMARY", "DESCRIPTION", "LOCATION", "COMMENT":
+		return true
+	}
+	return strings.HasPrefix(name, "X-")
+}
+
+func unescape(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
+// splitList splits a comma-separated text list, honouring escaped commas
+func splitList(value string) []string {
+	var items []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			items = append(items, strings.TrimSpace(unescape(value[start:i])))
+			start = i + 1
+		}
+	}
+	return append(items, strings.TrimSpace(unescape(value[start:])))
+}
+
+func mailAddress(value string) string {
+	if len(value) >= 7 && strings.EqualFold(value[:7], "mailto:") {
+		value = value[7:]
+	}
+	return strings.ToLower(value)
+}
+
+// parseTime parses a DATE or DATE-TIME value. Dates are returned at midnight
+// UTC like the rest of the calendar; floating times use the local zone.
+func parseTime(prop Property) (time.Time, bool, error) {
+	value := prop.Value
+	if prop.Params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		date, err := time.Parse("20060102", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+		}
+		return date, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+		}
+		return t, false, nil
+	}
+
+	location := time.Local
+	if tzid := prop.Params["TZID"]; tzid != "" {
+		loc, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown time zone %q", tzid)
+		}
+		location = loc
+	}
+
+	t, err := time.ParseInLocation("20060102T150405", value, location)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+	}
+	return t, false, nil
+}
+
+// ParseDuration parses an RFC 5545 duration such as "PT1H30M", "P1D" or "-P1W"
+func ParseDuration(value string) (time.Duration, error) {
+	s := value
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign = -1
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	s = s[1:]
+
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	var total time.Duration
+	number := ""
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			number += string(c)
+		case c == 'T':
+			if number != "" {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			units = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+		default:
+			unit, ok := units[c]
+			if !ok || number == "" {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			n, _ := strconv.Atoi(number)
+			total += time.Duration(n) * unit
+			number = ""
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return sign * total, nil
+}
@@ -0,0 +1,103 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const sample = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:new-year@example.com\r\n" +
+	"DTSTART;VALUE=DATE:20260101\r\n" +
+	"DTEND;VALUE=DATE:20260103\r\n" +
+	"SUMMARY:Новогодние\r\n" +
+	"  каникулы\r\n" +
+	"CATEGORIES:Holiday,Day off\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:sync@example.com\r\n" +
+	"DTSTART;TZID=Europe/Moscow:20251103T100000\r\n" +
+	"DURATION:PT1H30M\r\n" +
+	"SUMMARY:Sync\\, PROJ-42\r\n" +
+	"DESCRIPTION:Agenda:\\nstatus\r\n" +
+	"ORGANIZER;CN=\"Lead: Team\":mailto:Lead@example.com\r\n" +
+	"ATTENDEE;CN=Dev;ROLE=REQ-PARTICIPANT:mailto:dev@example.com\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"DESCRIPTION:Reminder\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParse(t *testing.T) {
+	events, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("events = %d, want 2", len(events))
+	}
+
+	holiday := events[0]
+	if !holiday.AllDay || holiday.Summary != "Новогодние каникулы" {
+		t.Errorf("holiday = %q, all-day %v", holiday.Summary, holiday.AllDay)
+	}
+	if days := holiday.Days(); len(days) != 2 || !days[1].Equal(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Days() = %v, want Jan 1 and Jan 2", days)
+	}
+	if len(holiday.Categories) != 2 || holiday.Categories[1] != "Day off" {
+		t.Errorf("Categories = %q", holiday.Categories)
+	}
+
+	meeting := events[1]
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	if !meeting.Start.Equal(time.Date(2025, 11, 3, 10, 0, 0, 0, moscow)) || meeting.End.Sub(meeting.Start) != 90*time.Minute {
+		t.Errorf("meeting = %v – %v, want 10:00 MSK for 1h30m", meeting.Start, meeting.End)
+	}
+	if meeting.Summary != "Sync, PROJ-42" || meeting.Description != "Agenda:\nstatus" {
+		t.Errorf("meeting text = %q / %q", meeting.Summary, meeting.Description)
+	}
+	if meeting.Organizer != "lead@example.com" || len(meeting.Attendees) != 1 || meeting.Attendees[0] != "dev@example.com" {
+		t.Errorf("meeting people = %q / %q", meeting.Organizer, meeting.Attendees)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		"unterminated": "BEGIN:VEVENT\nDTSTART:20250101\n",
+		"no start":     "BEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT\n",
+		"bad date":     "BEGIN:VEVENT\nDTSTART:2025-01-01\nEND:VEVENT\n",
+		"no colon":     "BEGIN:VEVENT\nDTSTART\nEND:VEVENT\n",
+	}
+	for name, input := range tests {
+		if _, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: Parse() error = nil", name)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"PT1H30M", 90 * time.Minute},
+		{"P1D", 24 * time.Hour},
+		{"P1W", 7 * 24 * time.Hour},
+		{"-PT15M", -15 * time.Minute},
+		{"P1DT2H", 26 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.value)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "PT", "1H", "PT1X", "P1H"} {
+		if _, err := ParseDuration(value); err == nil {
+			t.Errorf("ParseDuration(%q) error = nil", value)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package ics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxOccurrences bounds rule expansion for rules without COUNT or UNTIL
+const maxOccurrences = 10000
+
+// rule is the supported subset of an RRULE: FREQ=DAILY|WEEKLY|MONTHLY|YEARLY
+// with INTERVAL, COUNT, UNTIL and, for daily and weekly rules, BYDAY
+type rule struct {
+	freq     string
+	interval int
+	count    int
+	until    time.Time
+	byDay    []time.Weekday
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+func parseRule(value string, location *time.Location) (*rule, error) {
+	r := &rule{interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.freq = strings.ToUpper(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", val)
+			}
+			r.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", val)
+			}
+			r.count = n
+		case "UNTIL":
+			until, _, err := parseTime(Property{Value: val})
+			if err != nil {
+				return nil, err
+			}
+			if len(val) == len("20060102") {
+				// A date UNTIL includes the whole day in the event's zone
+				until = time.Date(until.Year(), until.Month(), until.Day(), 23, 59, 59, 0, location)
+			}
+			r.until = until
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				code = strings.ToUpper(code)
+				day, ok := weekdayCodes[code[max(len(code)-2, 0):]]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY %q", val)
+				}
+				r.byDay = append(r.byDay, day)
+			}
+		}
+	}
+
+	switch r.freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unsupported FREQ %q", r.freq)
+	}
+}
+
+// Expand returns the event instances that overlap [from, to), sorted by start.
+// Recurring events are expanded by RRULE minus EXDATE; occurrences overridden
+// by an instance with RECURRENCE-ID are replaced by that instance.
+func Expand(events []Event, from, to time.Time) ([]Event, error) {
+	overridden := make(map[string]bool)
+	for _, event := range events {
+		if !event.RecurrenceID.IsZero() {
+			overridden[occurrenceKey(event.UID, event.RecurrenceID)] = true
+		}
+	}
+
+	var instances []Event
+	for _, event := range events {
+		if event.RRule == "" || !event.RecurrenceID.IsZero() {
+			if overlaps(event, from, to) {
+				instances = append(instances, event)
+			}
+			continue
+		}
+
+		occurrences, err := expandRule(event, to)
+		if err != nil {
+			return nil, fmt.Errorf("event %q: %w", event.Summary, err)
+		}
+		for _, start := range occurrences {
+			if overridden[occurrenceKey(event.UID, start)] || excluded(event, start) {
+				continue
+			}
+			instance := event
+			instance.Start = start
+			instance.End = start.Add(event.End.Sub(event.Start))
+			if event.AllDay {
+				instance.End = start.AddDate(0, 0, int(event.End.Sub(event.Start)/(24*time.Hour)))
+			}
+			instance.RRule = ""
+			instance.RecurrenceID = start
+			if overlaps(instance, from, to) {
+				instances = append(instances, instance)
+			}
+		}
+	}
+
+	sort.SliceStable(instances, func(i, j int) bool {
+		return instances[i].Start.Before(instances[j].Start)
+	})
+	return instances, nil
+}
+
+// expandRule returns the starts of every occurrence of a recurring event before to
+func expandRule(event Event, to time.Time) ([]time.Time, error) {
+	location := event.Start.Location()
+	r, err := parseRule(event.RRule, location)
+	if err != nil {
+		return nil, err
+	}
+
+	var starts []time.Time
+	emit := func(start time.Time) bool {
+		if start.Before(event.Start) {
+			return true
+		}
+		if !start.Before(to) || (!r.until.IsZero() && start.After(r.until)) {
+			return false
+		}
+		starts = append(starts, start)
+		return (r.count == 0 || len(starts) < r.count) && len(starts) < maxOccurrences
+	}
+
+	start := event.Start
+	for period := 0; period < maxOccurrences; period++ {
+		switch r.freq {
+		case "DAILY":
+			day := start.AddDate(0, 0, period*r.interval)
+			if len(r.byDay) > 0 && !hasWeekday(r.byDay, day.Weekday()) {
+				if !day.Before(to) {
+					return starts, nil
+				}
+				continue
+			}
+			if !emit(day) {
+				return starts, nil
+			}
+
+		case "WEEKLY":
+			// Weeks start on Monday (WKST=MO)
+			offset := (int(start.Weekday()) + 6) % 7
+			monday := start.AddDate(0, 0, period*7*r.interval-offset)
+			days := r.byDay
+			if len(days) == 0 {
+				days = []time.Weekday{start.Weekday()}
+			}
+			for i := 0; i < 7; i++ {
+				day := monday.AddDate(0, 0, i)
+				if !hasWeekday(days, day.Weekday()) {
+					continue
+				}
+				if !emit(day) {
+					return starts, nil
+				}
+			}
+
+		case "MONTHLY", "YEARLY":
+			months := period * r.interval
+			if r.freq == "YEARLY" {
+				months *= 12
+			}
+			day := start.AddDate(0, months, 0)
+			if day.Day() != start.Day() {
+				continue // e.g. the 31st in a shorter month
+			}
+			if !emit(day) {
+				return starts, nil
+			}
+		}
+	}
+
+	return starts, nil
+}
+
+func hasWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+func excluded(event Event, start time.Time) bool {
+	for _, exDate := range event.ExDates {
+		if exDate.Equal(start) || (event.AllDay && exDate.Format("20060102") == start.Format("20060102")) {
+			return true
+		}
+	}
+	return false
+}
+
+func overlaps(event Event, from, to time.Time) bool {
+	end := event.End
+	if !end.After(event.Start) {
+		end = event.Start.Add(time.Nanosecond) // Zero-length events still count at their start
+	}
+	return event.Start.Before(to) && end.After(from)
+}
+
+func occurrenceKey(uid string, start time.Time) string {
+	return uid + "/" + start.UTC().Format("20060102T150405")
+}
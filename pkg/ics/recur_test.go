@@ -0,0 +1,85 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const recurring = `BEGIN:VCALENDAR
+BEGIN:VEVENT
+UID:standup
+DTSTART:20251103T070000Z
+DTEND:20251103T071500Z
+RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;UNTIL=20251130T000000Z
+EXDATE:20251105T070000Z
+SUMMARY:Standup
+END:VEVENT
+BEGIN:VEVENT
+UID:standup
+RECURRENCE-ID:20251107T070000Z
+DTSTART:20251107T090000Z
+DTEND:20251107T093000Z
+SUMMARY:Standup (moved)
+END:VEVENT
+BEGIN:VEVENT
+UID:retro
+DTSTART:20251104T120000Z
+DTEND:20251104T130000Z
+RRULE:FREQ=DAILY;INTERVAL=7;COUNT=2
+SUMMARY:Retro
+END:VEVENT
+BEGIN:VEVENT
+UID:new-year
+DTSTART;VALUE=DATE:20240101
+RRULE:FREQ=YEARLY
+SUMMARY:New Year
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestExpand(t *testing.T) {
+	events, err := Parse(strings.NewReader(recurring))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instances, err := Expand(events, time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC), time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+
+	var got []string
+	for _, instance := range instances {
+		got = append(got, instance.Start.Format("01-02 15:04")+" "+instance.Summary)
+	}
+	want := []string{
+		"11-03 07:00 Standup",
+		"11-04 12:00 Retro",
+		// 11-05 is excluded, 11-07 is moved
+		"11-07 09:00 Standup (moved)",
+		"11-10 07:00 Standup",
+		"11-11 12:00 Retro", // COUNT=2
+		"11-12 07:00 Standup",
+		"11-14 07:00 Standup",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expand() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Yearly all-day events repeat in later years
+	instances, err = Expand(events, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 1 || instances[0].Summary != "New Year" || !instances[0].End.Equal(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expand(2026-01-01) = %+v, want New Year", instances)
+	}
+}
+
+func TestExpand_UnsupportedRule(t *testing.T) {
+	events := []Event{{UID: "x", Start: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC), RRule: "FREQ=HOURLY"}}
+	if _, err := Expand(events, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("Expand() error = nil for FREQ=HOURLY")
+	}
+}
@@ -3,6 +3,7 @@ package random
 import (
 	"math"
 	"math/rand"
+	"sort"
 	"time"
 )
 
@@ -83,6 +84,22 @@ func SelectRandomWeekdayDates(week time.Time, n int) []time.Time {
 	return dates
 }
 
+// SelectRandomDates selects n random dates from candidates
+// Returns dates in chronological order; all candidates when n >= len(candidates)
+func SelectRandomDates(candidates []time.Time, n int) []time.Time {
+	indices := SelectRandomItems(len(candidates), n)
+
+	dates := make([]time.Time, len(indices))
+	for i, idx := range indices {
+		dates[i] = candidates[idx]
+	}
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	return dates
+}
+
 // SelectRandomItems selects n random items from slice
 // Returns indices of selected items
 func SelectRandomItems(totalCount, n int) []int {
@@ -3,6 +3,7 @@ package random
 import (
 	"math"
 	"testing"
+	"time"
 )
 
 func TestRandomize(t *testing.T) {
@@ -132,6 +133,37 @@ func TestSelectRandomDays(t *testing.T) {
 	}
 }
 
+func TestSelectRandomDates(t *testing.T) {
+	// Holiday week: only Tuesday, Wednesday and a working Saturday
+	candidates := []time.Time{
+		time.Date(2025, 11, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 8, 0, 0, 0, 0, time.UTC),
+	}
+
+	for i := 0; i < 50; i++ {
+		result := SelectRandomDates(candidates, 2)
+		if len(result) != 2 {
+			t.Fatalf("SelectRandomDates() returned %d dates, want 2", len(result))
+		}
+		if !result[0].Before(result[1]) {
+			t.Errorf("SelectRandomDates() = %v, want chronological unique dates", result)
+		}
+		for _, d := range result {
+			if !d.Equal(candidates[0]) && !d.Equal(candidates[1]) && !d.Equal(candidates[2]) {
+				t.Errorf("SelectRandomDates() returned %v, not a candidate", d)
+			}
+		}
+	}
+
+	if got := SelectRandomDates(candidates, 5); len(got) != 3 {
+		t.Errorf("SelectRandomDates(n > len) returned %d dates, want 3", len(got))
+	}
+	if got := SelectRandomDates(nil, 2); len(got) != 0 {
+		t.Errorf("SelectRandomDates(nil) returned %d dates, want 0", len(got))
+	}
+}
+
 func TestSelectRandomDaysDistribution(t *testing.T) {
 	// Test that random selection is actually random (statistical test)
 	n := 2